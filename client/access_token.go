@@ -1,6 +1,7 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,8 +9,8 @@ import (
 	"strings"
 )
 
-func (c *Client) GetAccessToken(owner int, tokenId string) (*AccessToken, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user/%s/access-token/%s", c.HostURL, strconv.Itoa(owner), tokenId), nil)
+func (c *Client) GetAccessToken(ctx context.Context, owner int, tokenId string) (*AccessToken, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/user/%s/access-token/%s", c.HostURL, strconv.Itoa(owner), tokenId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,13 +32,13 @@ func (c *Client) GetAccessToken(owner int, tokenId string) (*AccessToken, error)
 	return &token, nil
 }
 
-func (c *Client) CreateAccessToken(owner int, creationRequest AccessToken) (*AccessToken, error) {
+func (c *Client) CreateAccessToken(ctx context.Context, owner int, creationRequest AccessToken) (*AccessToken, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user/%s/access-token", c.HostURL, strconv.Itoa(owner)), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/user/%s/access-token", c.HostURL, strconv.Itoa(owner)), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +57,8 @@ func (c *Client) CreateAccessToken(owner int, creationRequest AccessToken) (*Acc
 	return &token, nil
 }
 
-func (c *Client) DeleteAccessToken(owner int, tokenId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/user/%s/access-token/%s", c.HostURL, strconv.Itoa(owner), tokenId), nil)
+func (c *Client) DeleteAccessToken(ctx context.Context, owner int, tokenId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/user/%s/access-token/%s", c.HostURL, strconv.Itoa(owner), tokenId), nil)
 	if err != nil {
 		return err
 	}
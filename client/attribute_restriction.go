@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetAttributeRestriction(attributeID string) (*AttributeRestriction, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), nil)
+func (c *Client) GetAttributeRestriction(ctx context.Context, attributeID string) (*AttributeRestriction, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetAttributeRestriction(attributeID string) (*AttributeRestrict
 	return &attribute, nil
 }
 
-func (c *Client) CreateAttributeRestriction(creationRequest AttributeRestriction) (*AttributeRestriction, error) {
+func (c *Client) CreateAttributeRestriction(ctx context.Context, creationRequest AttributeRestriction) (*AttributeRestriction, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/allowed-attribute", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/allowed-attribute", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateAttributeRestriction(creationRequest AttributeRestriction
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateAttributeRestriction(attributeID string, creationRequest AttributeRestriction) error {
+func (c *Client) UpdateAttributeRestriction(ctx context.Context, attributeID string, creationRequest AttributeRestriction) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateAttributeRestriction(attributeID string, creationRequest
 	return nil
 }
 
-func (c *Client) DeleteAttributeRestriction(attributeID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), nil)
+func (c *Client) DeleteAttributeRestriction(ctx context.Context, attributeID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/allowed-attribute/%s", c.HostURL, attributeID), nil)
 	if err != nil {
 		return err
 	}
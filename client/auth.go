@@ -0,0 +1,118 @@
+package anaml
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Authenticator - Attaches credentials to requests made to Anaml
+type Authenticator interface {
+	Authenticate(ctx context.Context, c *Client, req *http.Request) error
+}
+
+// Authenticators holding credentials which can expire implement this so
+// the client can throw them away and try again after a 401.
+type expiringAuthenticator interface {
+	Expire()
+}
+
+// BasicAuth - Username and password sent with every request.
+// Access tokens are also used this way, with the token's id as the username
+// and its secret as the password.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a *BasicAuth) Authenticate(ctx context.Context, c *Client, req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// TokenAuth - A bearer token sent with every request
+type TokenAuth struct {
+	Token string
+}
+
+func (a *TokenAuth) Authenticate(ctx context.Context, c *Client, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// LoginAuth - Exchanges a username and password for a session token on first
+// use, and logs in again when Anaml reports the session has expired.
+type LoginAuth struct {
+	Credentials AuthStruct
+
+	mu    sync.Mutex
+	token string
+}
+
+func (a *LoginAuth) Authenticate(ctx context.Context, c *Client, req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" {
+		token, err := c.login(ctx, a.Credentials)
+		if err != nil {
+			return err
+		}
+		a.token = token
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	return nil
+}
+
+func (a *LoginAuth) Expire() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+}
+
+// This deliberately doesn't use doRequest: the login endpoint is not branch
+// scoped and must not itself be authenticated.
+func (c *Client) login(ctx context.Context, credentials AuthStruct) (string, error) {
+	rb, err := json.Marshal(credentials)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/login", c.HostURL), bytes.NewReader(rb))
+	if err != nil {
+		return "", err
+	}
+	c.setHeaders(req)
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode >= 300 {
+		return "", fmt.Errorf("login failed: %w", newAPIError(res.StatusCode, body))
+	}
+
+	response := AuthResponse{}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return "", err
+	}
+	if response.Token == "" {
+		return "", errors.New("login failed, no token in response")
+	}
+
+	return response.Token, nil
+}
@@ -0,0 +1,64 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLoginAuthLogsInAgainAfterUnauthorized(t *testing.T) {
+	logins := 0
+	var seen []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			logins++
+			fmt.Fprintf(w, `{"token":"t%d"}`, logins)
+		case "/feature/1":
+			auth := r.Header.Get("Authorization")
+			seen = append(seen, auth)
+			if auth == "Bearer t1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"id":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c.Auth = &LoginAuth{Credentials: AuthStruct{Username: "admin", Password: "secret"}}
+	c.Retry.MaxRetries = 0
+
+	feature, err := c.GetFeature(context.Background(), "1")
+	if err != nil || feature == nil {
+		t.Fatalf("expected the feature after logging in again, got %v %v", feature, err)
+	}
+	if logins != 2 {
+		t.Errorf("expected 2 logins, got %d", logins)
+	}
+	if len(seen) != 2 || seen[0] != "Bearer t1" || seen[1] != "Bearer t2" {
+		t.Errorf("expected the request to be retried with the new token, got %v", seen)
+	}
+}
+
+func TestLoginAuthGivesUpAfterUnauthorizedTwice(t *testing.T) {
+	logins := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			logins++
+			w.Write([]byte(`{"token":"t"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	c.Auth = &LoginAuth{}
+	c.Retry.MaxRetries = 0
+
+	if _, err := c.GetFeature(context.Background(), "1"); err == nil {
+		t.Error("expected a 401 after logging in again to fail")
+	}
+	if logins != 2 {
+		t.Errorf("expected to log in again only once, got %d logins", logins)
+	}
+}
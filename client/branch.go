@@ -0,0 +1,107 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+func (c *Client) GetBranch(ctx context.Context, branchName string) (*Branch, error) {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "GET", fmt.Sprintf("%s/branch/%s", c.HostURL, url.PathEscape(branchName)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	branch := Branch{}
+	err = json.Unmarshal(body, &branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &branch, nil
+}
+
+// headCommit - The commit reads made with the context would currently see.
+// For reads on a branch, that is the head of the branch they go to, which
+// may be a pending ephemeral branch. Empty if reads aren't made on a branch.
+func (c *Client) headCommit(ctx context.Context) (string, error) {
+	param, version, ok := c.requestVersion(ctx)
+	if !ok || param == "commit" {
+		return version, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.HostURL, nil)
+	if err != nil {
+		return "", err
+	}
+	branchName, err := c.ephemeralVersion(req, version)
+	if err != nil {
+		return "", err
+	}
+
+	branch, err := c.GetBranch(ctx, branchName)
+	if err != nil {
+		return "", err
+	}
+	if branch == nil {
+		return "", fmt.Errorf("Branch %q does not exist", branchName)
+	}
+	return branch.Head.ID, nil
+}
+
+func (c *Client) CreateBranch(ctx context.Context, creationRequest BranchCreationRequest) (*Branch, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "POST", fmt.Sprintf("%s/branch", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetBranch(ctx, creationRequest.Name)
+}
+
+func (c *Client) DeleteBranch(ctx context.Context, branchName string) error {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "DELETE", fmt.Sprintf("%s/branch/%s", c.HostURL, url.PathEscape(branchName)), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateBranchIfMissing - Creates the branch from the given source unless it
+// already exists. Returns the branch either way.
+func (c *Client) CreateBranchIfMissing(ctx context.Context, creationRequest BranchCreationRequest) (*Branch, error) {
+	branch, err := c.GetBranch(ctx, creationRequest.Name)
+	if err != nil || branch != nil {
+		return branch, err
+	}
+
+	log.Printf("[INFO] Creating missing branch %s", creationRequest.Name)
+	return c.CreateBranch(ctx, creationRequest)
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetBranchProtection(branchProtectionId string) (*BranchProtection, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), nil)
+func (c *Client) GetBranchProtection(ctx context.Context, branchProtectionId string) (*BranchProtection, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetBranchProtection(branchProtectionId string) (*BranchProtecti
 	return &branchProtection, nil
 }
 
-func (c *Client) CreateBranchProtection(creationRequest BranchProtection) (*BranchProtection, error) {
+func (c *Client) CreateBranchProtection(ctx context.Context, creationRequest BranchProtection) (*BranchProtection, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/branch-protection", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/branch-protection", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateBranchProtection(creationRequest BranchProtection) (*Bran
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateBranchProtection(branchProtectionId string, creationRequest BranchProtection) error {
+func (c *Client) UpdateBranchProtection(ctx context.Context, branchProtectionId string, creationRequest BranchProtection) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateBranchProtection(branchProtectionId string, creationReque
 	return nil
 }
 
-func (c *Client) DeleteBranchProtection(branchProtectionId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), nil)
+func (c *Client) DeleteBranchProtection(ctx context.Context, branchProtectionId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/branch-protection/%s", c.HostURL, branchProtectionId), nil)
 	if err != nil {
 		return err
 	}
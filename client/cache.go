@@ -0,0 +1,183 @@
+package anaml
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Kinds of object which are read through the cache. Each is listed in full
+// with a GET to /{kind} the first time one of them is read on a branch.
+var cachedKinds = map[string]bool{
+	"cluster":           true,
+	"destination":       true,
+	"entity":            true,
+	"entity-mapping":    true,
+	"entity-population": true,
+	"feature":           true,
+	"feature-set":       true,
+	"feature-store":     true,
+	"feature-template":  true,
+	"metrics-set":       true,
+	"source":            true,
+	"table":             true,
+}
+
+// readCache - Serves GETs of single objects from a listing of all objects of
+// that kind, so refreshing a large project takes a handful of requests
+// rather than one per object. Objects missing from a listing are still
+// fetched individually, so a truncated listing can never make an object
+// look deleted.
+type readCache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+type cacheKey struct {
+	kind   string
+	branch string
+}
+
+type cacheEntry struct {
+	mu      sync.Mutex
+	loaded  bool
+	objects map[string][]byte
+}
+
+// EnableReadCache - Turns on prefetching of objects on first read
+func (c *Client) EnableReadCache() {
+	c.cache = &readCache{entries: make(map[cacheKey]*cacheEntry)}
+}
+
+// Query parameters which only describe how a write is made, rather than
+// which object it's made to.
+var writeOnlyParams = []string{"commitMessage"}
+
+// Splits a request for /{kind}/{id} into its parts. Requests with any query
+// parameters other than the branch and write-only parameters are not for a
+// plain object, and aren't cached.
+func (c *Client) cacheTarget(req *http.Request) (cacheKey, string, bool) {
+	query := req.URL.Query()
+	branch := query.Get("branch")
+	query.Del("branch")
+	for _, param := range writeOnlyParams {
+		query.Del(param)
+	}
+	if len(query) > 0 {
+		return cacheKey{}, "", false
+	}
+
+	path := req.URL.Path
+	if host, err := url.Parse(c.HostURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(host.Path, "/"))
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 || !cachedKinds[parts[0]] {
+		return cacheKey{}, "", false
+	}
+
+	return cacheKey{kind: parts[0], branch: branch}, parts[1], true
+}
+
+// Returns the object the request is for, if it can be served from the cache.
+func (c *Client) cachedResponse(req *http.Request) ([]byte, bool) {
+	if c.cache == nil || req.Method != http.MethodGet {
+		return nil, false
+	}
+
+	key, id, ok := c.cacheTarget(req)
+	if !ok {
+		return nil, false
+	}
+
+	entry := c.cache.entry(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if !entry.loaded {
+		entry.objects = c.listForCache(req, key)
+		entry.loaded = true
+	}
+
+	object, ok := entry.objects[id]
+	return object, ok
+}
+
+// Forgets an object once it has been written, so the next read goes to
+// Anaml. New objects are never in a listing, so creates need no action.
+func (c *Client) invalidateCached(req *http.Request) {
+	if c.cache == nil || isRead(req) {
+		return
+	}
+
+	key, id, ok := c.cacheTarget(req)
+	if !ok {
+		return
+	}
+
+	entry := c.cache.entry(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	delete(entry.objects, id)
+}
+
+func (cache *readCache) entry(key cacheKey) *cacheEntry {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		cache.entries[key] = entry
+	}
+	return entry
+}
+
+// A failed listing leaves the cache empty for this kind, so every read
+// falls back to fetching the single object.
+func (c *Client) listForCache(req *http.Request, key cacheKey) map[string][]byte {
+	listURL := *req.URL
+	listURL.Path = listURL.Path[:strings.LastIndex(strings.TrimSuffix(listURL.Path, "/"), "/")]
+	listURL.RawQuery = ""
+
+	listReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, listURL.String(), nil)
+	if err != nil {
+		return nil
+	}
+	if key.branch != "" {
+		q := listReq.URL.Query()
+		q.Add("branch", key.branch)
+		listReq.URL.RawQuery = q.Encode()
+	}
+
+	log.Printf("[DEBUG] Prefetching all %s objects", key.kind)
+	body, err := c.send(listReq)
+	if err != nil || body == nil {
+		log.Printf("[WARN] Couldn't prefetch %s objects, reading them individually: %v", key.kind, err)
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		log.Printf("[WARN] Couldn't prefetch %s objects, reading them individually: %v", key.kind, err)
+		return nil
+	}
+
+	objects := make(map[string][]byte, len(items))
+	for _, item := range items {
+		identified := struct {
+			ID json.RawMessage `json:"id"`
+		}{}
+		if err := json.Unmarshal(item, &identified); err != nil || identified.ID == nil {
+			continue
+		}
+		objects[strings.Trim(string(identified.ID), `"`)] = item
+	}
+
+	log.Printf("[DEBUG] Prefetched %d %s objects", len(objects), key.kind)
+	return objects
+}
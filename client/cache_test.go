@@ -0,0 +1,113 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCacheInvalidatedByWriteWithCommitMessage(t *testing.T) {
+	version := "v1"
+	gets := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/feature":
+			w.Write([]byte(`[{"id":1,"version":"` + version + `","name":"a"}]`))
+		case r.Method == "GET" && r.URL.Path == "/feature/1":
+			gets++
+			w.Write([]byte(`{"id":1,"version":"` + version + `","name":"b"}`))
+		case r.Method == "PUT" && r.URL.Path == "/feature/1":
+			version = "v2"
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c.EnableReadCache()
+
+	ctx := context.Background()
+	if _, err := c.GetFeature(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateFeature(WithCommitMessage(ctx, "Rename"), "1", Feature{}); err != nil {
+		t.Fatal(err)
+	}
+
+	feature, err := c.GetFeature(ctx, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gets != 1 || feature.Version != "v2" {
+		t.Errorf("expected the updated feature to be read from Anaml, got version %s after %d reads", feature.Version, gets)
+	}
+}
+
+func TestReadCache(t *testing.T) {
+	cases := []struct {
+		name    string
+		prefix  string
+		listing string
+		write   string
+		lists   int
+		gets    int
+	}{
+		{"served from listing", "", `[{"id":1},{"id":2}]`, "", 1, 0},
+		{"host path prefix", "/api", `[{"id":1},{"id":2}]`, "", 1, 0},
+		{"missing from listing", "", `[{"id":1}]`, "", 1, 1},
+		{"invalidated by update", "", `[{"id":1},{"id":2}]`, "PUT", 1, 1},
+		{"invalidated by delete", "", `[{"id":1},{"id":2}]`, "DELETE", 1, 1},
+		{"listing fails", "", "", "", 1, 3},
+	}
+
+	for _, tc := range cases {
+		lists, gets := 0, 0
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "GET" && r.URL.Path == tc.prefix+"/feature":
+				lists++
+				if r.URL.Query().Get("branch") != "official" {
+					t.Errorf("%s: expected the listing to be of the branch, got %s", tc.name, r.URL)
+				}
+				if tc.listing == "" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.Write([]byte(tc.listing))
+			case r.Method == "GET" && strings.HasPrefix(r.URL.Path, tc.prefix+"/feature/"):
+				gets++
+				w.Write([]byte(`{"id":` + strings.TrimPrefix(r.URL.Path, tc.prefix+"/feature/") + `}`))
+			case r.Method == tc.write && r.URL.Path == tc.prefix+"/feature/1":
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		c.HostURL += tc.prefix
+		c.Retry.MaxRetries = 0
+		c.EnableReadCache()
+
+		ctx := context.Background()
+		for _, id := range []string{"1", "2"} {
+			if feature, err := c.GetFeature(ctx, id); err != nil || feature == nil || strconv.Itoa(feature.ID) != id {
+				t.Errorf("%s: expected feature %s, got %v %v", tc.name, id, feature, err)
+			}
+		}
+		var err error
+		switch tc.write {
+		case "PUT":
+			err = c.UpdateFeature(ctx, "1", Feature{})
+		case "DELETE":
+			err = c.DeleteFeature(ctx, "1")
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		}
+		if feature, err := c.GetFeature(ctx, "1"); err != nil || feature == nil || feature.ID != 1 {
+			t.Errorf("%s: expected feature 1, got %v %v", tc.name, feature, err)
+		}
+
+		if lists != tc.lists || gets != tc.gets {
+			t.Errorf("%s: expected %d listings and %d reads, got %d and %d", tc.name, tc.lists, tc.gets, lists, gets)
+		}
+	}
+}
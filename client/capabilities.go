@@ -0,0 +1,57 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverFeature - Part of the schema which older Anaml releases reject
+type serverFeature struct {
+	Description string
+	Since       ServerVersion
+}
+
+// The first Anaml release supporting each feature, kept in step with
+// Anaml's release notes. Plans setting a feature's blocks fail against older
+// servers, so a version set too high rejects plans Anaml would accept.
+var (
+	bigtableDestinations  = serverFeature{"bigtable destinations", ServerVersion{Major: 1, Minor: 3}}
+	snowflakeDestinations = serverFeature{"snowflake destinations", ServerVersion{Major: 1, Minor: 3}}
+	eventStoreTables      = serverFeature{"event_store tables", ServerVersion{Major: 1, Minor: 2}}
+	tableDomainModelling  = serverFeature{"domain_modelling on tables", ServerVersion{Major: 1, Minor: 4}}
+)
+
+// requireFeature - Errors if the server is too old for the feature. If the
+// version can't be found out, the feature is allowed and Anaml left to
+// reject it.
+func (c *Client) requireFeature(ctx context.Context, feature serverFeature) error {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		log.Printf("[WARN] Couldn't find the Anaml version to check support for %s: %v", feature.Description, err)
+		return nil
+	}
+	if version == nil || version.AtLeast(feature.Since) {
+		return nil
+	}
+	return fmt.Errorf("%s require Anaml %s or later, but %s is version %s", feature.Description, feature.Since, c.HostURL, version)
+}
+
+// requireFeatureDiff fails plans which set any of the keys when the server
+// doesn't support the feature.
+func requireFeatureDiff(feature serverFeature, keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		c, ok := m.(*Client)
+		if !ok {
+			return nil
+		}
+		for _, key := range keys {
+			if _, set := d.GetOk(key); set {
+				return c.requireFeature(ctx, feature)
+			}
+		}
+		return nil
+	}
+}
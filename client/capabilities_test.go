@@ -0,0 +1,48 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRequireFeature(t *testing.T) {
+	feature := serverFeature{"widgets", ServerVersion{Major: 1, Minor: 4}}
+
+	for info, supported := range map[string]bool{
+		`{"version":"1.3.0"}`: false,
+		`{"version":"1.4.0"}`: true,
+		`{"version":"dev"}`:   true,
+		`{}`:                  true,
+	} {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(info))
+		})
+
+		err := c.requireFeature(context.Background(), feature)
+		if supported && err != nil {
+			t.Errorf("%s: expected widgets to be supported, got %v", info, err)
+		}
+		if !supported && err == nil {
+			t.Errorf("%s: expected widgets to be rejected", info)
+		}
+	}
+}
+
+func TestDestinationPlanRequiresBigtableSupport(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version":"1.2.0"}`))
+	})
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "rows",
+		"bigtable": []interface{}{map[string]interface{}{"project": "p", "instance": "i"}},
+	})
+	_, err := ResourceDestination().Diff(context.Background(), nil, config, c)
+	if err == nil || !strings.Contains(err.Error(), "bigtable destinations require Anaml 1.3.0") {
+		t.Errorf("expected the plan to be rejected for the server version, got %v", err)
+	}
+}
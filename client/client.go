@@ -3,10 +3,12 @@ package anaml
 import (
 	"bytes"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
+	"sync"
+	"text/template"
 	"time"
 )
 
@@ -15,28 +17,53 @@ const HostURL string = "http://localhost:8080"
 
 // Client -
 type Client struct {
-	HostURL    string
-	HTTPClient *http.Client
-	Auth       *AuthStruct
-	Branch     *string
+	HostURL      string
+	HTTPClient   *http.Client
+	Auth         Authenticator
+	Branch       *string
+	Retry        RequestRetryPolicy
+	ExtraHeaders map[string]string
+
+	// DefaultLabels and DefaultAttributes - Added to every object written
+	// which has labels and attributes
+	DefaultLabels     []string
+	DefaultAttributes map[string]string
+
+	// CommitMessage - Template for the message of commits made by writes
+	// which don't set their own. Nil leaves the message to Anaml.
+	CommitMessage *template.Template
+
+	// PreviewCluster - The cluster previews are run on. Nil uses the only
+	// cluster marked as a preview cluster.
+	PreviewCluster *int
+
+	cache       *readCache
+	ephemeral   *ephemeralBranch
+	limiter     *requestLimiter
+	branchLocks branchLocks
+
+	versionMu    sync.Mutex
+	versionKnown bool
+	version      *ServerVersion
 }
 
-// AuthStruct -
+// AuthStruct - Credentials for the login endpoint
 type AuthStruct struct {
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"password" sensitive:"true"`
 }
 
-// AuthResponse -
+// AuthResponse - Session token issued by the login endpoint
 type AuthResponse struct {
-	Token string `json:"token"`
+	Token string `json:"token" sensitive:"true"`
 }
 
 // NewClient -
-func NewClient(host, username, password, branch *string, timeout time.Duration) (*Client, error) {
+func NewClient(host *string, auth Authenticator, branch *string, timeout time.Duration) (*Client, error) {
 	c := Client{
 		HTTPClient: &http.Client{Timeout: timeout},
 		HostURL:    HostURL,
+		Retry:      DefaultRetryPolicy,
 	}
 
 	if host != nil {
@@ -47,61 +74,167 @@ func NewClient(host, username, password, branch *string, timeout time.Duration)
 		c.Branch = branch
 	}
 
-	if (username != nil) && (password != nil) {
-		c.Auth = &AuthStruct{Username: *username, Password: *password}
+	if auth != nil {
+		c.Auth = auth
 	} else {
-		return nil, errors.New("No username or password set")
+		return nil, errors.New("No credentials set")
 	}
 
 	return &c, nil
 }
 
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	req.SetBasicAuth(c.Auth.Username, c.Auth.Password)
-	req.Header.Set("Content-Type", "application/json")
+	if param, version, ok := c.requestVersion(req.Context()); ok {
+		if param == "branch" {
+			var err error
+			version, err = c.ephemeralVersion(req, version)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-	if c.Branch != nil {
 		q := req.URL.Query()
-		q.Add("branch", *c.Branch)
+		q.Add(param, version)
+		if message, ok := req.Context().Value(commitMessageContextKey).(string); ok && !isRead(req) {
+			q.Add("commitMessage", message)
+		}
 		req.URL.RawQuery = q.Encode()
 	}
 
-	log.Printf("[DEBUG] Request: %v\n", req)
+	if version, ok := req.Context().Value(ifMatchContextKey).(string); ok && version != "" && !isRead(req) {
+		req.Header.Set("If-Match", strconv.Quote(version))
+	}
 
-	if req.Body != nil {
-		requestBody, err := ioutil.ReadAll(req.Body)
-		if err == nil {
-			reader0 := ioutil.NopCloser(bytes.NewBuffer(requestBody))
-			reader1 := ioutil.NopCloser(bytes.NewBuffer(requestBody))
-			log.Printf("[DEBUG] Request body: %q", reader0)
-			req.Body = reader1
-		}
+	if body, ok := c.cachedResponse(req); ok {
+		log.Printf("[DEBUG] Request: %s %s, served from cache", req.Method, req.URL)
+		return body, nil
 	}
+	c.invalidateCached(req)
 
-	res, err := c.HTTPClient.Do(req)
+	unlock := c.lockBranch(req)
+	defer unlock()
+
+	body, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("[DEBUG] Response: %v\n", res)
+	c.recordCommit(req)
+	return body, nil
+}
 
-	defer res.Body.Close()
+// Stores the head of the branch a write went to in the request's commit
+// recorder. This is done while the branch is still locked, so the head is
+// the commit the write made.
+func (c *Client) recordCommit(req *http.Request) {
+	recorder, ok := req.Context().Value(commitRecorderContextKey).(*commitRecorder)
+	branchName := req.URL.Query().Get("branch")
+	if !ok || isRead(req) || branchName == "" {
+		return
+	}
 
-	responseBody, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	branch, err := c.GetBranch(req.Context(), branchName)
+	if err != nil || branch == nil {
+		log.Printf("[WARN] Couldn't find the commit made on branch %s: %v", branchName, err)
+		return
 	}
+	recorder.ID = branch.Head.ID
+}
 
-	reader := ioutil.NopCloser(bytes.NewBuffer(responseBody))
-	log.Printf("[DEBUG] Request body: %q", reader)
+// Sends the request as is, retrying transient failures.
+func (c *Client) send(req *http.Request) ([]byte, error) {
+	c.setHeaders(req)
 
-	if res.StatusCode == 404 {
-		return nil, nil
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if res.StatusCode >= 300 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, responseBody)
+	reauthenticated := false
+	for attempt := 0; ; attempt++ {
+		if requestBody != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(requestBody))
+		}
+
+		if err := c.Auth.Authenticate(req.Context(), c, req); err != nil {
+			return nil, err
+		}
+
+		log.Printf("[DEBUG] Request: %s %s, headers: %v", req.Method, req.URL, c.redactHeaders(req.Header))
+		if requestBody != nil {
+			log.Printf("[DEBUG] Request body: %s", redactBody(requestBody))
+		}
+
+		release, err := c.limiter.acquire(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.HTTPClient.Do(req)
+		if err != nil {
+			release()
+			if attempt < c.Retry.MaxRetries && retryableError(req, err) {
+				if err := c.waitToRetry(req, attempt, nil, err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		log.Printf("[DEBUG] Response: %s, headers: %v", res.Status, c.redactHeaders(res.Header))
+
+		responseBody, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		release()
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("[DEBUG] Response body: %s", redactBody(responseBody))
+
+		// The session may have expired since we logged in. Log in again
+		// once, without counting it as a retry.
+		if expiring, ok := c.Auth.(expiringAuthenticator); ok && res.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			log.Printf("[DEBUG] Session expired, logging in again")
+			expiring.Expire()
+			reauthenticated = true
+			attempt--
+			continue
+		}
+
+		if attempt < c.Retry.MaxRetries && retryableResponse(req, res) {
+			if err := c.waitToRetry(req, attempt, res, res.Status); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+
+		if res.StatusCode >= 300 {
+			return nil, newAPIError(res.StatusCode, responseBody)
+		}
+
+		return responseBody, nil
 	}
+}
+
+func (c *Client) waitToRetry(req *http.Request, attempt int, res *http.Response, reason string) error {
+	wait := c.Retry.backoff(attempt, res)
+	log.Printf("[WARN] %s %s failed (%s), retrying in %s (%d/%d)", req.Method, req.URL.Path, reason, wait, attempt+1, c.Retry.MaxRetries)
+	return sleepContext(req.Context(), wait)
+}
 
-	return responseBody, err
+func (c *Client) setHeaders(req *http.Request) {
+	for name, value := range c.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
 }
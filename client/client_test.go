@@ -0,0 +1,24 @@
+package anaml
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for the official branch whose requests are
+// served by handler. The server is closed when the test finishes.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	host := server.URL
+	branch := "official"
+	c, err := NewClient(&host, &BasicAuth{}, &branch, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetCluster(clusterID string) (*Cluster, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), nil)
+func (c *Client) GetCluster(ctx context.Context, clusterID string) (*Cluster, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetCluster(clusterID string) (*Cluster, error) {
 	return &cluster, nil
 }
 
-func (c *Client) CreateCluster(creationRequest Cluster) (*Cluster, error) {
+func (c *Client) CreateCluster(ctx context.Context, creationRequest Cluster) (*Cluster, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/cluster", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/cluster", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateCluster(creationRequest Cluster) (*Cluster, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateCluster(clusterID string, creationRequest Cluster) error {
+func (c *Client) UpdateCluster(ctx context.Context, clusterID string, creationRequest Cluster) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateCluster(clusterID string, creationRequest Cluster) error
 	return nil
 }
 
-func (c *Client) DeleteCluster(clusterID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), nil)
+func (c *Client) DeleteCluster(ctx context.Context, clusterID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/cluster/%s", c.HostURL, clusterID), nil)
 	if err != nil {
 		return err
 	}
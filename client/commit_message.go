@@ -0,0 +1,50 @@
+package anaml
+
+import (
+	"bytes"
+	"os"
+	"text/template"
+)
+
+// CommitMessageData - The values a commit message template can refer to,
+// such as {{.Operation}} or {{.Object}}.
+//
+// Terraform doesn't tell providers the address of the resource being
+// applied, so Object is the resource type and the object's name in Anaml
+// (or id, for objects without a name), such as anaml_feature.spend.
+type CommitMessageData struct {
+	Operation    string
+	ResourceType string
+	Name         string
+	ID           string
+	Object       string
+	Branch       string
+	Workspace    string
+}
+
+// ParseCommitMessage - Parses a commit message template. Referring to a
+// value which doesn't exist is an error.
+func ParseCommitMessage(text string) (*template.Template, error) {
+	return template.New("commit_message").Option("missingkey=error").Parse(text)
+}
+
+func renderCommitMessage(tmpl *template.Template, data CommitMessageData) (string, error) {
+	var message bytes.Buffer
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", err
+	}
+	return message.String(), nil
+}
+
+// The workspace is only known to the provider when it was chosen with
+// TF_WORKSPACE, as terraform workspace select doesn't pass it on. Otherwise
+// it's empty. Provider configuration can use terraform.workspace instead.
+func terraformWorkspace() string {
+	return os.Getenv("TF_WORKSPACE")
+}
+
+// commitRecorder - Holds the commit made by a write, for resources which
+// expose it.
+type commitRecorder struct {
+	ID string
+}
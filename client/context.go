@@ -0,0 +1,108 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey int
+
+const (
+	branchContextKey contextKey = iota
+	commitContextKey
+	unversionedContextKey
+	commitMessageContextKey
+	commitRecorderContextKey
+	ifMatchContextKey
+	readOnlyContextKey
+)
+
+// WithBranch - Sends requests made with the returned context to the given
+// branch, rather than the branch the client was configured with
+func WithBranch(ctx context.Context, branch string) context.Context {
+	return context.WithValue(ctx, branchContextKey, branch)
+}
+
+// WithCommit - Reads made with the returned context see objects as they were
+// at the given commit, rather than at the head of a branch
+func WithCommit(ctx context.Context, commit string) context.Context {
+	return context.WithValue(ctx, commitContextKey, commit)
+}
+
+// WithCommitMessage - Writes made with the returned context are committed
+// with the given message
+func WithCommitMessage(ctx context.Context, message string) context.Context {
+	return context.WithValue(ctx, commitMessageContextKey, message)
+}
+
+// WithIfMatch - Writes made with the returned context only succeed if the
+// object is still at the given version. Otherwise Anaml responds with a 412.
+func WithIfMatch(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, ifMatchContextKey, version)
+}
+
+// The commit a write made with the returned context creates is stored in
+// the returned recorder.
+func recordCommit(ctx context.Context) (context.Context, *commitRecorder) {
+	recorder := &commitRecorder{}
+	return context.WithValue(ctx, commitRecorderContextKey, recorder), recorder
+}
+
+// Requests made with the returned context only read from Anaml, even when
+// they aren't GETs, as with previews. They're treated as reads, so they don't
+// create an ephemeral branch, wait on writes to their branch or invalidate
+// cached objects, and are retried like GETs.
+func readOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyContextKey, true)
+}
+
+// Whether the request only reads from Anaml.
+func isRead(req *http.Request) bool {
+	readOnly, _ := req.Context().Value(readOnlyContextKey).(bool)
+	return req.Method == http.MethodGet || readOnly
+}
+
+// cancellationContext - Only the deadline and cancellation of a context.
+type cancellationContext struct {
+	context.Context
+}
+
+func (cancellationContext) Value(key interface{}) interface{} {
+	return nil
+}
+
+// Requests made with the returned context are cancelled along with ctx, but
+// carry none of its values, such as the If-Match or commit message of the
+// write they are made on behalf of.
+func withoutValues(ctx context.Context) context.Context {
+	return cancellationContext{ctx}
+}
+
+// Requests about branches and commits themselves aren't made against a
+// version, whatever the client or context says.
+func withoutVersion(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unversionedContextKey, true)
+}
+
+// The query parameter and value selecting the version of the catalog a
+// request is for, if any. A commit takes precedence over a branch.
+func (c *Client) requestVersion(ctx context.Context) (string, string, bool) {
+	if unversioned, _ := ctx.Value(unversionedContextKey).(bool); unversioned {
+		return "", "", false
+	}
+	if commit, ok := ctx.Value(commitContextKey).(string); ok && commit != "" {
+		return "commit", commit, true
+	}
+	if branch := c.requestBranch(ctx); branch != nil {
+		return "branch", *branch, true
+	}
+	return "", "", false
+}
+
+// The branch a request should go to, if any.
+func (c *Client) requestBranch(ctx context.Context) *string {
+	if branch, ok := ctx.Value(branchContextKey).(string); ok && branch != "" {
+		return &branch
+	}
+	return c.Branch
+}
@@ -1,51 +1,46 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceCluster() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceClusterRead,
+		ReadContext: dataSourceClusterRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceCluster().Schema),
+			lookupSchema("cluster", "name", true),
+		}),
 	}
 }
 
-func dataSourceClusterRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
-
-	cluster, err := c.FindCluster(name)
-	if err != nil {
-		return err
-	}
-
-	if cluster == nil {
-		d.SetId("")
-		return nil
-	}
 
-	d.SetId(strconv.Itoa(cluster.ID))
-	if err := d.Set("name", cluster.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", cluster.Description); err != nil {
-		return err
-	}
-	return err
+	return objectLookup{
+		kind:    "cluster",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			cluster, err := c.FindCluster(ctx, name)
+			if err != nil || cluster == nil {
+				return nil, err
+			}
+			return &cluster.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			clusters, err := c.ListClusters(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedClusters(clusters), nil
+		},
+		read: resourceClusterRead,
+	}.readContext(ctx, d, m)
 }
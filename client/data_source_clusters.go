@@ -0,0 +1,47 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceClusters() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Clusters matching the given filters",
+
+		ReadContext: dataSourceClustersRead,
+
+		Schema: listFilterSchema(),
+	}
+}
+
+func dataSourceClustersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	clusters, err := c.ListClusters(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := filterListed(d, listedClusters(clusters))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "clusters", objects)
+}
+
+func listedClusters(clusters []Cluster) []listedObject {
+	objects := make([]listedObject, 0, len(clusters))
+	for _, cluster := range clusters {
+		objects = append(objects, listedObject{
+			ID:         cluster.ID,
+			Name:       cluster.Name,
+			Labels:     cluster.Labels,
+			Attributes: cluster.Attributes,
+		})
+	}
+	return objects
+}
@@ -1,52 +1,46 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceDestination() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceDestinationRead,
+		ReadContext: dataSourceDestinationRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceDestination().Schema),
+			lookupSchema("destination", "name", true),
+		}),
 	}
 }
 
-func dataSourceDestinationRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceDestinationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
-
-	destination, err := c.FindDestination(name)
-	if err != nil {
-		return err
-	}
-
-	if destination == nil {
-		d.SetId("")
-		return nil
-	}
 
-	d.SetId(strconv.Itoa(destination.ID))
-
-	if err := d.Set("name", destination.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", destination.Description); err != nil {
-		return err
-	}
-	return err
+	return objectLookup{
+		kind:    "destination",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			destination, err := c.FindDestination(ctx, name)
+			if err != nil || destination == nil {
+				return nil, err
+			}
+			return &destination.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			destinations, err := c.ListDestinations(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedDestinations(destinations), nil
+		},
+		read: resourceDestinationRead,
+	}.readContext(ctx, d, m)
 }
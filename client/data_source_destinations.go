@@ -0,0 +1,47 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceDestinations() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Destinations matching the given filters",
+
+		ReadContext: dataSourceDestinationsRead,
+
+		Schema: listFilterSchema(),
+	}
+}
+
+func dataSourceDestinationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	destinations, err := c.ListDestinations(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := filterListed(d, listedDestinations(destinations))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "destinations", objects)
+}
+
+func listedDestinations(destinations []Destination) []listedObject {
+	objects := make([]listedObject, 0, len(destinations))
+	for _, destination := range destinations {
+		objects = append(objects, listedObject{
+			ID:         destination.ID,
+			Name:       destination.Name,
+			Labels:     destination.Labels,
+			Attributes: destination.Attributes,
+		})
+	}
+	return objects
+}
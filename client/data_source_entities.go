@@ -0,0 +1,55 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceEntities() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Entities matching the given filters",
+
+		ReadContext: dataSourceEntitiesRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			listFilterSchema(),
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+func dataSourceEntitiesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	ctx, err := versionContext(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	entities, err := c.ListEntities(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := filterListed(d, listedEntities(entities))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "entities", objects)
+}
+
+func listedEntities(entities []Entity) []listedObject {
+	objects := make([]listedObject, 0, len(entities))
+	for _, entity := range entities {
+		objects = append(objects, listedObject{
+			ID:         entity.ID,
+			Name:       entity.Name,
+			Labels:     entity.Labels,
+			Attributes: entity.Attributes,
+		})
+	}
+	return objects
+}
@@ -1,52 +1,52 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceEntity() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: dataSourceEntityRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceEntity().Schema),
+			lookupSchema("entity", "name", true),
+			dataSourceVersionSchema(),
+		}),
 	}
 }
 
-func dataSourceEntityRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceEntityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
 
-	feature, err := c.FindEntityByName(name)
+	ctx, err := versionContext(ctx, d, c)
 	if err != nil {
-		return err
-	}
-
-	if feature == nil {
-		d.SetId("")
-		return nil
+		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(feature.ID))
-
-	if err := d.Set("name", feature.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", feature.Description); err != nil {
-		return err
-	}
-	return nil
+	return objectLookup{
+		kind:    "entity",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			entity, err := c.FindEntityByName(ctx, name)
+			if err != nil || entity == nil {
+				return nil, err
+			}
+			return &entity.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			entities, err := c.ListEntities(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedEntities(entities), nil
+		},
+		read: resourceEntityRead,
+	}.readContext(ctx, d, m)
 }
@@ -1,52 +1,65 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceEntityPopulation() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceEntityPopulationRead,
+		ReadContext: dataSourceEntityPopulationRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceEntityPopulation().Schema),
+			lookupSchema("entity population", "name", true),
+			dataSourceVersionSchema(),
+		}),
 	}
 }
 
-func dataSourceEntityPopulationRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceEntityPopulationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
 
-	population, err := c.FindEntityPopulationByName(name)
+	ctx, err := versionContext(ctx, d, c)
 	if err != nil {
-		return err
-	}
-
-	if population == nil {
-		d.SetId("")
-		return nil
+		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(population.ID))
+	return objectLookup{
+		kind:    "entity population",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			entityPopulation, err := c.FindEntityPopulationByName(ctx, name)
+			if err != nil || entityPopulation == nil {
+				return nil, err
+			}
+			return &entityPopulation.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			entityPopulations, err := c.ListEntityPopulations(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedEntityPopulations(entityPopulations), nil
+		},
+		read: resourceEntityPopulationRead,
+	}.readContext(ctx, d, m)
+}
 
-	if err := d.Set("name", population.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", population.Description); err != nil {
-		return err
+func listedEntityPopulations(entityPopulations []EntityPopulation) []listedObject {
+	objects := make([]listedObject, 0, len(entityPopulations))
+	for _, entityPopulation := range entityPopulations {
+		objects = append(objects, listedObject{
+			ID:         entityPopulation.ID,
+			Name:       entityPopulation.Name,
+			Labels:     entityPopulation.Labels,
+			Attributes: entityPopulation.Attributes,
+		})
 	}
-	return nil
+	return objects
 }
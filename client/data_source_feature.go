@@ -1,8 +1,9 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -10,46 +11,44 @@ func DataSourceFeature() *schema.Resource {
 	return &schema.Resource{
 		Description: "A single Feature",
 
-		Read: dataSourceFeatureRead,
+		ReadContext: dataSourceFeatureRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Description: "The Feature's name",
-				Required:    true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceFeature().Schema),
+			lookupSchema("feature", "name", true),
+			dataSourceVersionSchema(),
+		}),
 	}
 }
 
-func dataSourceFeatureRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceFeatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
 
-	feature, err := c.FindFeatureByName(name)
+	ctx, err := versionContext(ctx, d, c)
 	if err != nil {
-		return err
-	}
-
-	if feature == nil {
-		d.SetId("")
-		return nil
+		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(feature.ID))
-
-	if err := d.Set("name", feature.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", feature.Description); err != nil {
-		return err
-	}
-	return nil
+	return objectLookup{
+		kind:    "feature",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			feature, err := c.FindFeatureByName(ctx, name)
+			if err != nil || feature == nil {
+				return nil, err
+			}
+			return &feature.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			features, err := c.ListFeatures(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedFeatures(features), nil
+		},
+		read: resourceFeatureRead,
+	}.readContext(ctx, d, m)
 }
@@ -0,0 +1,236 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Attributes of anaml_feature which can define an event feature to preview
+// inline, rather than an existing feature.
+var featurePreviewInlineKeys = []string{
+	"table", "select", "filter", "aggregation", "post_aggregation", "hours", "days", "months", "rows",
+}
+
+func DataSourceFeaturePreview() *schema.Resource {
+	return &schema.Resource{
+		Description: "A sample of a Feature's values and statistics over them, computed on a preview cluster",
+
+		ReadContext: dataSourceFeaturePreviewRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			featurePreviewInlineSchema(),
+			{
+				"feature": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The id of an existing Feature to preview",
+					ExactlyOneOf: []string{"feature", "table"},
+					ValidateFunc: validateAnamlIdentifier(),
+				},
+				"cluster": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The preview cluster to run on. Defaults to the provider's preview_cluster, or the only cluster with is_preview_cluster set.",
+					ValidateFunc: validateAnamlIdentifier(),
+				},
+				"date": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The date to compute the feature for, as YYYY-MM-DD",
+					ValidateFunc: validateDate(),
+				},
+				"limit": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					Description:  "The number of rows to return",
+					ValidateFunc: validation.IntBetween(1, 1000),
+				},
+				"sample": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "A sample of entities and the feature's value for each",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"entity": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"value": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"summary": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Statistics over all of the feature's values. min, max and mean are only set for numeric features.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"count": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"null_count": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"distinct_count": {
+								Type:     schema.TypeInt,
+								Computed: true,
+							},
+							"min": {
+								Type:     schema.TypeFloat,
+								Computed: true,
+							},
+							"max": {
+								Type:     schema.TypeFloat,
+								Computed: true,
+							},
+							"mean": {
+								Type:     schema.TypeFloat,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+// The inline definition takes its attributes from anaml_feature, so they
+// validate the same way.
+func featurePreviewInlineSchema() map[string]*schema.Schema {
+	resource := ResourceFeature().Schema
+
+	res := make(map[string]*schema.Schema, len(featurePreviewInlineKeys))
+	for _, key := range featurePreviewInlineKeys {
+		s := *resource[key]
+		s.Required = false
+		s.Optional = true
+		s.RequiredWith = nil
+		res[key] = &s
+	}
+
+	res["table"].Description = "The id of the Table to compute an inline event feature from, rather than previewing an existing feature"
+	res["select"].RequiredWith = []string{"table"}
+	res["aggregation"].RequiredWith = []string{"table"}
+	return res
+}
+
+func dataSourceFeaturePreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	ctx, err := versionContext(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	previewRequest := FeaturePreviewRequest{
+		Date:  d.Get("date").(string),
+		Limit: d.Get("limit").(int),
+	}
+
+	if cluster := getAnamlIdPointer(d, "cluster"); cluster != nil {
+		previewRequest.Cluster = *cluster
+	} else {
+		previewRequest.Cluster, err = c.previewCluster(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var id string
+	if feature := getAnamlIdPointer(d, "feature"); feature != nil {
+		previewRequest.FeatureID = feature
+		id = fmt.Sprintf("feature/%d/%s", *feature, previewRequest.Date)
+	} else {
+		previewRequest.Feature = buildPreviewFeature(d)
+		id = fmt.Sprintf("table/%d/%s", previewRequest.Feature.Table, previewRequest.Date)
+	}
+
+	preview, err := c.PreviewFeature(ctx, previewRequest)
+	if err != nil {
+		return apiErrorDiagnostics(err, featureFieldAttributes)
+	}
+	if preview == nil {
+		d.SetId("")
+		if previewRequest.FeatureID == nil {
+			return notFoundError("table", "table", d.Get("table").(string))
+		}
+		return notFoundError("feature", "feature", d.Get("feature").(string))
+	}
+
+	d.SetId(id)
+
+	if err := d.Set("sample", flattenFeaturePreviewRows(preview.Rows)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("summary", flattenFeatureSummary(preview.Summary)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// An event feature from the inline attributes, in the same way as
+// anaml_feature builds one.
+func buildPreviewFeature(d resourceGetter) *Feature {
+	table, _ := strconv.Atoi(d.Get("table").(string))
+
+	feature := Feature{
+		Type:   "event",
+		Table:  table,
+		Window: expandEventWindow(d),
+	}
+	expandFeatureExpressions(d, &feature)
+
+	return &feature
+}
+
+func flattenFeaturePreviewRows(rows []FeaturePreviewRow) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, map[string]interface{}{
+			"entity": previewValue(row.Entity),
+			"value":  previewValue(row.Value),
+		})
+	}
+	return res
+}
+
+// Strings are returned as they are, and any other value as its JSON.
+func previewValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+func flattenFeatureSummary(summary FeatureSummary) []map[string]interface{} {
+	single := map[string]interface{}{
+		"count":          summary.Count,
+		"null_count":     summary.NullCount,
+		"distinct_count": summary.DistinctCount,
+	}
+	if summary.Min != nil {
+		single["min"] = *summary.Min
+	}
+	if summary.Max != nil {
+		single["max"] = *summary.Max
+	}
+	if summary.Mean != nil {
+		single["mean"] = *summary.Mean
+	}
+	return []map[string]interface{}{single}
+}
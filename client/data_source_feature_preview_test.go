@@ -0,0 +1,42 @@
+package anaml
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPreviewFeatureMatchesFeature(t *testing.T) {
+	for _, window := range []map[string]interface{}{
+		{},
+		{"hours": 6},
+		{"days": 7},
+		{"months": 1},
+		{"rows": 3},
+	} {
+		raw := map[string]interface{}{
+			"table":            "3",
+			"select":           "amount",
+			"filter":           "amount > 0",
+			"aggregation":      "sum",
+			"post_aggregation": "result * 2",
+		}
+		for key, value := range window {
+			raw[key] = value
+		}
+
+		preview := buildPreviewFeature(schema.TestResourceDataRaw(t, DataSourceFeaturePreview().Schema, raw))
+
+		raw["name"] = "spend"
+		feature, err := buildFeature(schema.TestResourceDataRaw(t, ResourceFeature().Schema, raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(preview.Window, feature.Window) || !reflect.DeepEqual(preview.Filter, feature.Filter) ||
+			!reflect.DeepEqual(preview.PostAggExpr, feature.PostAggExpr) || preview.Select != feature.Select || preview.Table != feature.Table {
+			t.Errorf("%v: expected the preview to match the feature, got %+v and %+v", window, preview, feature)
+		}
+	}
+}
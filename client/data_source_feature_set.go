@@ -1,52 +1,52 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceFeatureSet() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: dataSourceFeatureSetRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceFeatureSet().Schema),
+			lookupSchema("feature set", "name", true),
+			dataSourceVersionSchema(),
+		}),
 	}
 }
 
-func dataSourceFeatureSetRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceFeatureSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
 
-	feature, err := c.FindFeatureSetByName(name)
+	ctx, err := versionContext(ctx, d, c)
 	if err != nil {
-		return err
-	}
-
-	if feature == nil {
-		d.SetId("")
-		return nil
+		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(feature.ID))
-
-	if err := d.Set("name", feature.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", feature.Description); err != nil {
-		return err
-	}
-	return nil
+	return objectLookup{
+		kind:    "feature set",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			featureSet, err := c.FindFeatureSetByName(ctx, name)
+			if err != nil || featureSet == nil {
+				return nil, err
+			}
+			return &featureSet.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			featureSets, err := c.ListFeatureSets(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedFeatureSets(featureSets), nil
+		},
+		read: resourceFeatureSetRead,
+	}.readContext(ctx, d, m)
 }
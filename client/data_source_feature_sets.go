@@ -0,0 +1,55 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFeatureSets() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Feature Sets matching the given filters",
+
+		ReadContext: dataSourceFeatureSetsRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			listFilterSchema(),
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+func dataSourceFeatureSetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	ctx, err := versionContext(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	featureSets, err := c.ListFeatureSets(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := filterListed(d, listedFeatureSets(featureSets))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "feature_sets", objects)
+}
+
+func listedFeatureSets(featureSets []FeatureSet) []listedObject {
+	objects := make([]listedObject, 0, len(featureSets))
+	for _, featureSet := range featureSets {
+		objects = append(objects, listedObject{
+			ID:         featureSet.ID,
+			Name:       featureSet.Name,
+			Labels:     featureSet.Labels,
+			Attributes: featureSet.Attributes,
+		})
+	}
+	return objects
+}
@@ -1,52 +1,59 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceFeatureStore() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: dataSourceFeatureStoreRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceFeatureStore().Schema),
+			lookupSchema("feature store", "name", true),
+		}),
 	}
 }
 
-func dataSourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceFeatureStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
-
-	feature, err := c.FindFeatureStoreByName(name)
-	if err != nil {
-		return err
-	}
-
-	if feature == nil {
-		d.SetId("")
-		return nil
-	}
 
-	d.SetId(strconv.Itoa(feature.ID))
+	return objectLookup{
+		kind:    "feature store",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			featureStore, err := c.FindFeatureStoreByName(ctx, name)
+			if err != nil || featureStore == nil {
+				return nil, err
+			}
+			return &featureStore.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			featureStores, err := c.ListFeatureStores(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedFeatureStores(featureStores), nil
+		},
+		read: resourceFeatureStoreRead,
+	}.readContext(ctx, d, m)
+}
 
-	if err := d.Set("name", feature.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", feature.Description); err != nil {
-		return err
+func listedFeatureStores(featureStores []FeatureStore) []listedObject {
+	objects := make([]listedObject, 0, len(featureStores))
+	for _, featureStore := range featureStores {
+		objects = append(objects, listedObject{
+			ID:         featureStore.ID,
+			Name:       featureStore.Name,
+			Labels:     featureStore.Labels,
+			Attributes: featureStore.Attributes,
+		})
 	}
-	return nil
+	return objects
 }
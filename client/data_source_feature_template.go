@@ -1,52 +1,65 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceFeatureTemplate() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceFeatureRead,
+		ReadContext: dataSourceFeatureTemplateRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceFeatureTemplate().Schema),
+			lookupSchema("feature template", "name", true),
+			dataSourceVersionSchema(),
+		}),
 	}
 }
 
-func dataSourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceFeatureTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
 
-	feature, err := c.FindFeatureTemplateByName(name)
+	ctx, err := versionContext(ctx, d, c)
 	if err != nil {
-		return err
-	}
-
-	if feature == nil {
-		d.SetId("")
-		return nil
+		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(feature.ID))
+	return objectLookup{
+		kind:    "feature template",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			featureTemplate, err := c.FindFeatureTemplateByName(ctx, name)
+			if err != nil || featureTemplate == nil {
+				return nil, err
+			}
+			return &featureTemplate.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			featureTemplates, err := c.ListFeatureTemplates(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedFeatureTemplates(featureTemplates), nil
+		},
+		read: resourceFeatureTemplateRead,
+	}.readContext(ctx, d, m)
+}
 
-	if err := d.Set("name", feature.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", feature.Description); err != nil {
-		return err
+func listedFeatureTemplates(featureTemplates []FeatureTemplate) []listedObject {
+	objects := make([]listedObject, 0, len(featureTemplates))
+	for _, featureTemplate := range featureTemplates {
+		objects = append(objects, listedObject{
+			ID:         featureTemplate.ID,
+			Name:       featureTemplate.Name,
+			Labels:     featureTemplate.Labels,
+			Attributes: featureTemplate.Attributes,
+		})
 	}
-	return nil
+	return objects
 }
@@ -0,0 +1,108 @@
+package anaml
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceFeatures() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Features matching the given filters",
+
+		ReadContext: dataSourceFeaturesRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			{
+				"table": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Only include event features derived from this Table",
+					ValidateFunc: validateAnamlIdentifier(),
+				},
+				"entity": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Only include features for this Entity. These are row features over it, and event features on tables with it as an entity.",
+					ValidateFunc: validateAnamlIdentifier(),
+				},
+			},
+			listFilterSchema(),
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+func dataSourceFeaturesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	ctx, err := versionContext(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	features, err := c.ListFeatures(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tableID := getAnamlIdPointer(d, "table")
+	entityID := getAnamlIdPointer(d, "entity")
+
+	// Event features take their entities from their table, so the tables
+	// are only needed when filtering by entity.
+	var tableEntities map[int]map[string]string
+	if entityID != nil {
+		tables, err := c.ListTables(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tableEntities = make(map[int]map[string]string, len(tables))
+		for _, table := range tables {
+			if table.EventInfo != nil {
+				tableEntities[table.ID] = table.EventInfo.Entities
+			}
+		}
+	}
+
+	matching := make([]Feature, 0, len(features))
+	for _, feature := range features {
+		if tableID != nil && feature.Table != *tableID {
+			continue
+		}
+		if entityID != nil && !featureHasEntity(feature, *entityID, tableEntities) {
+			continue
+		}
+		matching = append(matching, feature)
+	}
+
+	objects, err := filterListed(d, listedFeatures(matching))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "features", objects)
+}
+
+func featureHasEntity(feature Feature, entityID int, tableEntities map[int]map[string]string) bool {
+	if feature.Table == 0 {
+		return feature.EntityID == entityID
+	}
+	_, ok := tableEntities[feature.Table][strconv.Itoa(entityID)]
+	return ok
+}
+
+func listedFeatures(features []Feature) []listedObject {
+	objects := make([]listedObject, 0, len(features))
+	for _, feature := range features {
+		objects = append(objects, listedObject{
+			ID:         feature.ID,
+			Name:       feature.Name,
+			Labels:     feature.Labels,
+			Attributes: feature.Attributes,
+		})
+	}
+	return objects
+}
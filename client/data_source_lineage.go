@@ -0,0 +1,177 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Object types as they're named in Terraform, and the kinds Anaml's lineage
+// API knows them by.
+var lineageKinds = map[string]string{
+	"cluster":                  "cluster",
+	"destination":              "destination",
+	"entity":                   "entity",
+	"entity_population":        "entity-population",
+	"feature":                  "feature",
+	"feature_set":              "feature-set",
+	"feature_store":            "feature-store",
+	"feature_template":         "feature-template",
+	"metrics_set":              "metrics-set",
+	"source":                   "source",
+	"table":                    "table",
+	"view_materialisation_job": "view-materialisation",
+}
+
+func DataSourceLineage() *schema.Resource {
+	return &schema.Resource{
+		Description: "The objects upstream and downstream of an object in Anaml's lineage graph",
+
+		ReadContext: dataSourceLineageRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			{
+				"object_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The id of the object to find the lineage of",
+					ValidateFunc: validateAnamlIdentifier(),
+				},
+				"object_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The type of the object, such as table, feature or feature_set",
+					ValidateFunc: validation.StringInSlice(lineageTypes(), false),
+				},
+				"depth": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					Description:  "How many steps away from the object to follow the lineage. 0 follows it all the way.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"upstream": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The objects whose data flows into the object, nearest first",
+					Elem:        lineageNodeSchema(),
+				},
+				"downstream": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The objects the object's data flows into, nearest first",
+					Elem:        lineageNodeSchema(),
+				},
+			},
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+func lineageNodeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"distance": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many steps away from the object this one is",
+			},
+		},
+	}
+}
+
+func lineageTypes() []string {
+	types := make([]string, 0, len(lineageKinds))
+	for objectType := range lineageKinds {
+		types = append(types, objectType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func dataSourceLineageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	objectID := d.Get("object_id").(string)
+	objectType := d.Get("object_type").(string)
+	depth := d.Get("depth").(int)
+
+	ctx, err := versionContext(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kind := lineageKinds[objectType]
+	lineage, err := c.GetLineage(ctx, kind, objectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if lineage == nil {
+		d.SetId("")
+		return notFoundError(strings.ReplaceAll(objectType, "_", " "), "object_id", objectID)
+	}
+
+	id, err := strconv.Atoi(objectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	from := LineageNode{Type: kind, ID: id}
+
+	d.SetId(fmt.Sprintf("%s/%s", objectType, objectID))
+
+	if err := d.Set("upstream", flattenLineage(lineage.Upstream(from, depth))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("downstream", flattenLineage(lineage.Downstream(from, depth))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Objects are ordered by distance, then by type and id, so the result is
+// stable however Anaml orders the graph.
+func flattenLineage(nodes []LineageDistance) []map[string]interface{} {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Distance != nodes[j].Distance {
+			return nodes[i].Distance < nodes[j].Distance
+		}
+		if nodes[i].Type != nodes[j].Type {
+			return nodes[i].Type < nodes[j].Type
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	res := make([]map[string]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		res = append(res, map[string]interface{}{
+			"id":       strconv.Itoa(node.ID),
+			"type":     lineageType(node.Type),
+			"distance": node.Distance,
+		})
+	}
+	return res
+}
+
+// The Terraform name for a kind of object in Anaml's lineage graph.
+func lineageType(kind string) string {
+	for objectType, known := range lineageKinds {
+		if known == kind {
+			return objectType
+		}
+	}
+	return strings.ReplaceAll(kind, "-", "_")
+}
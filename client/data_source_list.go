@@ -0,0 +1,167 @@
+package anaml
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Plural data sources list every object of a kind and return the ids and
+// names of those matching their filters, most often to for_each over them.
+// Every filter given must match.
+
+// listedObject - The parts of an object the common filters look at.
+type listedObject struct {
+	ID         int
+	Name       string
+	Labels     []string
+	Attributes []Attribute
+}
+
+func listFilterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"labels": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Only include objects with all of these labels",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+		"attribute": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Only include objects with this attribute. Any value matches when value is not given.",
+			Elem:        attributeSchema(),
+		},
+		"name_prefix": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Only include objects whose name starts with this prefix",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Only include objects whose name matches this regular expression",
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The ids of the matching objects, ordered by name",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The names of the matching objects, in the same order as ids",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// filterListed keeps the objects which match the labels, attributes and name
+// filters.
+func filterListed(d *schema.ResourceData, objects []listedObject) ([]listedObject, error) {
+	labels := expandStringList(d.Get("labels").(*schema.Set).List())
+	attributes := d.Get("attribute").([]interface{})
+	prefix := d.Get("name_prefix").(string)
+
+	var nameRegex *regexp.Regexp
+	if pattern := d.Get("name_regex").(string); pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		nameRegex = compiled
+	}
+
+	res := make([]listedObject, 0, len(objects))
+	for _, object := range objects {
+		if !strings.HasPrefix(object.Name, prefix) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(object.Name) {
+			continue
+		}
+		if !hasLabels(object.Labels, labels) {
+			continue
+		}
+		if !hasAttributes(object.Attributes, attributes) {
+			continue
+		}
+		res = append(res, object)
+	}
+	return res, nil
+}
+
+func hasLabels(labels []string, wanted []string) bool {
+	present := make(map[string]bool)
+	for _, label := range labels {
+		present[label] = true
+	}
+	for _, label := range wanted {
+		if !present[label] {
+			return false
+		}
+	}
+	return true
+}
+
+// Each configured attribute must be on the object. One configured without a
+// value matches the key with any value.
+func hasAttributes(attributes []Attribute, wanted []interface{}) bool {
+	for _, raw := range wanted {
+		filter := raw.(map[string]interface{})
+		key := filter["key"].(string)
+		value := filter["value"].(string)
+
+		found := false
+		for _, attribute := range attributes {
+			if attribute.Key == key && (value == "" || attribute.Value == value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// setListed stores the matching objects' ids and names, ordered by name so
+// the result doesn't change with the order Anaml lists them in.
+func setListed(d *schema.ResourceData, kind string, objects []listedObject) diag.Diagnostics {
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Name < objects[j].Name
+	})
+
+	ids := make([]string, 0, len(objects))
+	names := make([]string, 0, len(objects))
+	for _, object := range objects {
+		ids = append(ids, strconv.Itoa(object.ID))
+		names = append(names, object.Name)
+	}
+
+	d.SetId(kind)
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
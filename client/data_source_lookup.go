@@ -0,0 +1,173 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Singular data sources find their object by exactly one of its id, its
+// name, or a selector of labels and attributes. Ids survive renames, and a
+// selector lets an object be found by how it's tagged.
+
+// objectLookup - How a singular data source finds its object. The object
+// is then read by the matching resource's read, with its id set. list is
+// only needed by data sources with a selector.
+type objectLookup struct {
+	kind    string
+	nameKey string
+	find    func(ctx context.Context, name string) (*int, error)
+	list    func(ctx context.Context) ([]listedObject, error)
+	read    schema.ReadContextFunc
+}
+
+// lookupSchema lets a data source be given an id or a name, or, when
+// withSelector is set, a selector.
+func lookupSchema(kind string, nameKey string, withSelector bool) map[string]*schema.Schema {
+	exactlyOneOf := []string{"id", nameKey}
+	if withSelector {
+		exactlyOneOf = append(exactlyOneOf, "selector")
+	}
+
+	res := map[string]*schema.Schema{
+		"id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  fmt.Sprintf("The %s's id", kind),
+			ExactlyOneOf: exactlyOneOf,
+			ValidateFunc: validateAnamlIdentifier(),
+		},
+		nameKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  fmt.Sprintf("The %s's %s", kind, nameKey),
+			ExactlyOneOf: exactlyOneOf,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+
+	if withSelector {
+		res["selector"] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			Description:  fmt.Sprintf("Labels and attributes which exactly one %s has", kind),
+			ExactlyOneOf: exactlyOneOf,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"labels": {
+						Type:         schema.TypeSet,
+						Optional:     true,
+						Description:  "Labels the object must all have",
+						AtLeastOneOf: []string{"selector.0.labels", "selector.0.attribute"},
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+					"attribute": {
+						Type:         schema.TypeList,
+						Optional:     true,
+						Description:  "Attributes the object must have. Any value matches when value is not given.",
+						AtLeastOneOf: []string{"selector.0.labels", "selector.0.attribute"},
+						Elem:         attributeSchema(),
+					},
+				},
+			},
+		}
+	}
+
+	return res
+}
+
+func (l objectLookup) readContext(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	key, value := "id", d.Get("id").(string)
+
+	if value == "" {
+		if name := d.Get(l.nameKey).(string); name != "" {
+			id, err := l.find(ctx, name)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if id == nil {
+				d.SetId("")
+				return l.notFound(l.nameKey, name)
+			}
+			key, value = l.nameKey, name
+			d.SetId(strconv.Itoa(*id))
+		} else {
+			id, diags := l.selectOne(ctx, d)
+			if id == "" {
+				d.SetId("")
+				return diags
+			}
+			value = id
+			d.SetId(id)
+		}
+	} else {
+		d.SetId(value)
+	}
+
+	diags := l.read(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	if d.Id() == "" {
+		return append(diags, l.notFound(key, value)...)
+	}
+	return diags
+}
+
+func (l objectLookup) notFound(key string, value string) diag.Diagnostics {
+	return notFoundError(l.kind, key, value)
+}
+
+// selectOne finds the id of the only object matching the selector.
+func (l objectLookup) selectOne(ctx context.Context, d *schema.ResourceData) (string, diag.Diagnostics) {
+	objects, err := l.list(ctx)
+	if err != nil {
+		return "", diag.FromErr(err)
+	}
+
+	selector := d.Get("selector.0").(map[string]interface{})
+	labels := expandStringList(selector["labels"].(*schema.Set).List())
+	attributes := selector["attribute"].([]interface{})
+
+	var matches []listedObject
+	for _, object := range objects {
+		if hasLabels(object.Labels, labels) && hasAttributes(object.Attributes, attributes) {
+			matches = append(matches, object)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return strconv.Itoa(matches[0].ID), nil
+	case 0:
+		return "", diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("No %s matches the selector", l.kind),
+			Detail:        fmt.Sprintf("No %s in Anaml has all of the selector's labels and attributes.", l.kind),
+			AttributePath: cty.GetAttrPath("selector"),
+		}}
+	default:
+		names := make([]string, 0, len(matches))
+		for _, object := range matches {
+			names = append(names, fmt.Sprintf("%s (%d)", object.Name, object.ID))
+		}
+		return "", diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("More than one %s matches the selector", l.kind),
+			Detail:        fmt.Sprintf("The selector must match exactly one %s, but matches %s. Add labels or attributes to the selector, or look the %s up by id or %s.", l.kind, strings.Join(names, ", "), l.kind, l.nameKey),
+			AttributePath: cty.GetAttrPath("selector"),
+		}}
+	}
+}
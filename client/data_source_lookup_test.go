@@ -0,0 +1,83 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/branch/official" {
+			w.Write([]byte(`{"name":"official","head":{"id":"c1"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	events := []interface{}{map[string]interface{}{"source": "1", "folder": "events"}}
+	preview := func(raw map[string]interface{}) map[string]interface{} {
+		raw["cluster"] = "1"
+		raw["date"] = "2024-01-31"
+		return raw
+	}
+
+	cases := []struct {
+		name       string
+		dataSource *schema.Resource
+		raw        map[string]interface{}
+		summary    string
+		path       string
+	}{
+		{"entity by id", DataSourceEntity(), map[string]interface{}{"id": "7"}, "entity not found", "id"},
+		{"entity by name", DataSourceEntity(), map[string]interface{}{"name": "missing"}, "entity not found", "name"},
+		{"lineage", DataSourceLineage(), map[string]interface{}{"object_id": "7", "object_type": "feature_set"}, "feature set not found", "object_id"},
+		{"table schema of table", DataSourceTableSchema(), map[string]interface{}{"table": "7"}, "table not found", "table"},
+		{"table schema of source", DataSourceTableSchema(), map[string]interface{}{"source": events}, "source not found", "source"},
+		{"feature preview", DataSourceFeaturePreview(), preview(map[string]interface{}{"feature": "7"}), "feature not found", "feature"},
+		{"inline feature preview", DataSourceFeaturePreview(), preview(map[string]interface{}{"table": "3", "select": "amount", "aggregation": "sum"}), "table not found", "table"},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, tc.dataSource.Schema, tc.raw)
+
+		diags := tc.dataSource.ReadContext(context.Background(), d, c)
+		if !diags.HasError() || diags[0].Severity != diag.Error || diags[0].Summary != tc.summary {
+			t.Errorf("%s: expected a %s error, got %v", tc.name, tc.summary, diags)
+		} else if !diags[0].AttributePath.Equals(cty.GetAttrPath(tc.path)) {
+			t.Errorf("%s: expected the error against %s, got %v", tc.name, tc.path, diags[0].AttributePath)
+		}
+		if d.Id() != "" {
+			t.Errorf("%s: expected no id, got %q", tc.name, d.Id())
+		}
+	}
+}
+
+func TestDataSourcePinnedToBranchHead(t *testing.T) {
+	var commit string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/branch/official":
+			w.Write([]byte(`{"name":"official","head":{"id":"c1"}}`))
+		case "/entity/7":
+			commit = r.URL.Query().Get("commit")
+			w.Write([]byte(`{"id":7,"name":"customer"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resource := DataSourceEntity()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"id": "7"})
+
+	if diags := resource.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if commit != "c1" || d.Get("resolved_commit") != "c1" {
+		t.Errorf("expected the read to be pinned to the head of the branch, got %q and resolved commit %v", commit, d.Get("resolved_commit"))
+	}
+}
@@ -1,49 +1,46 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceSource() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceSourceRead,
+		ReadContext: dataSourceSourceRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceSource().Schema),
+			lookupSchema("source", "name", true),
+		}),
 	}
 }
 
-func dataSourceSourceRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
-
-	source, err := c.FindSource(name)
-	if err != nil {
-		return err
-	}
-
-	if source == nil {
-		d.SetId("")
-		return nil
-	}
 
-	d.SetId(strconv.Itoa(source.ID))
-
-	if err := d.Set("description", source.Description); err != nil {
-		return err
-	}
-	return err
+	return objectLookup{
+		kind:    "source",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			source, err := c.FindSource(ctx, name)
+			if err != nil || source == nil {
+				return nil, err
+			}
+			return &source.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			sources, err := c.ListSources(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedSources(sources), nil
+		},
+		read: resourceSourceRead,
+	}.readContext(ctx, d, m)
 }
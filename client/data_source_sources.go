@@ -0,0 +1,47 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceSources() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Sources matching the given filters",
+
+		ReadContext: dataSourceSourcesRead,
+
+		Schema: listFilterSchema(),
+	}
+}
+
+func dataSourceSourcesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	sources, err := c.ListSources(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := filterListed(d, listedSources(sources))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "sources", objects)
+}
+
+func listedSources(sources []Source) []listedObject {
+	objects := make([]listedObject, 0, len(sources))
+	for _, source := range sources {
+		objects = append(objects, listedObject{
+			ID:         source.ID,
+			Name:       source.Name,
+			Labels:     source.Labels,
+			Attributes: source.Attributes,
+		})
+	}
+	return objects
+}
@@ -1,52 +1,52 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceTable() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTableRead,
+		ReadContext: dataSourceTableRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"description": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-		},
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceTable().Schema),
+			lookupSchema("table", "name", true),
+			dataSourceVersionSchema(),
+		}),
 	}
 }
 
-func dataSourceTableRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceTableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	name := d.Get("name").(string)
 
-	feature, err := c.FindTableByName(name)
+	ctx, err := versionContext(ctx, d, c)
 	if err != nil {
-		return err
-	}
-
-	if feature == nil {
-		d.SetId("")
-		return nil
+		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(feature.ID))
-
-	if err := d.Set("name", feature.Name); err != nil {
-		return err
-	}
-	if err := d.Set("description", feature.Description); err != nil {
-		return err
-	}
-	return nil
+	return objectLookup{
+		kind:    "table",
+		nameKey: "name",
+		find: func(ctx context.Context, name string) (*int, error) {
+			table, err := c.FindTableByName(ctx, name)
+			if err != nil || table == nil {
+				return nil, err
+			}
+			return &table.ID, nil
+		},
+		list: func(ctx context.Context) ([]listedObject, error) {
+			tables, err := c.ListTables(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return listedTables(tables), nil
+		},
+		read: resourceTableRead,
+	}.readContext(ctx, d, m)
 }
@@ -0,0 +1,127 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTableSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: "The column names and Spark types Anaml infers for a source location or an existing Table",
+
+		ReadContext: dataSourceTableSchemaRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			{
+				"source": {
+					Type:          schema.TypeList,
+					Optional:      true,
+					MaxItems:      1,
+					Description:   "A location in a source, as given to a Root table's source block. Sources don't belong to a branch, so this can't be given with branch or commit",
+					Elem:          sourceSchema(),
+					ExactlyOneOf:  []string{"source", "table"},
+					ConflictsWith: []string{"branch", "commit"},
+				},
+				"table": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The id of an existing Table",
+					ValidateFunc: validateAnamlIdentifier(),
+				},
+				"columns": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The columns, in the order Anaml gives them",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"type": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The column's Spark type, such as string, timestamp or array<bigint>",
+							},
+							"nullable": {
+								Type:     schema.TypeBool,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"column_names": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The names of the columns, in the same order as columns",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+func dataSourceTableSchemaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var tableSchema *TableSchema
+	var id string
+
+	if tableID := d.Get("table").(string); tableID != "" {
+		ctx, err := versionContext(ctx, d, c)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		tableSchema, err = c.GetTableSchema(ctx, tableID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if tableSchema == nil {
+			d.SetId("")
+			return notFoundError("table", "table", tableID)
+		}
+		id = fmt.Sprintf("table/%s", tableID)
+	} else {
+		location := expandSourceReferences(d.Get("source.0").(map[string]interface{}))
+
+		var err error
+		tableSchema, err = c.InferSourceSchema(ctx, *location)
+		if err != nil {
+			return apiErrorDiagnostics(err, nil)
+		}
+		if tableSchema == nil {
+			d.SetId("")
+			return notFoundError("source", "source", strconv.Itoa(location.SourceID))
+		}
+		id = fmt.Sprintf("source/%d/%s/%s%s%s", location.SourceID, location.Type, location.Folder, location.TableName, location.Topic)
+	}
+
+	d.SetId(id)
+
+	columns := make([]map[string]interface{}, 0, len(tableSchema.Columns))
+	names := make([]string, 0, len(tableSchema.Columns))
+	for _, column := range tableSchema.Columns {
+		columns = append(columns, map[string]interface{}{
+			"name":     column.Name,
+			"type":     column.Type,
+			"nullable": column.Nullable,
+		})
+		names = append(names, column.Name)
+	}
+
+	if err := d.Set("columns", columns); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("column_names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
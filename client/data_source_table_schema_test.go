@@ -0,0 +1,57 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDataSourceTableSchemaSourceRetried(t *testing.T) {
+	attempts := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/source/schema" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"columns":[{"name":"event_time","type":"timestamp","nullable":false}]}`))
+	})
+	c.Retry.MinWait = time.Millisecond
+	c.Retry.MaxWait = time.Millisecond
+
+	resource := DataSourceTableSchema()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"source": []interface{}{map[string]interface{}{"source": "1", "folder": "events"}},
+	})
+
+	if diags := resource.ReadContext(context.Background(), d, c); diags != nil {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the schema request to be retried once, got %d attempts", attempts)
+	}
+	if d.Get("column_names.0") != "event_time" {
+		t.Errorf("expected the inferred columns, got %v", d.Get("column_names"))
+	}
+}
+
+func TestDataSourceTableSchemaSourceConflictsWithVersion(t *testing.T) {
+	resource := DataSourceTableSchema()
+	for _, key := range []string{"branch", "commit"} {
+		diags := resource.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"source": []interface{}{map[string]interface{}{"source": "1", "folder": "events"}},
+			key:      "abc",
+		}))
+		if !diags.HasError() {
+			t.Errorf("expected source to conflict with %s", key)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceTables() *schema.Resource {
+	return &schema.Resource{
+		Description: "The ids and names of Tables matching the given filters",
+
+		ReadContext: dataSourceTablesRead,
+
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			listFilterSchema(),
+			dataSourceVersionSchema(),
+		}),
+	}
+}
+
+func dataSourceTablesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	ctx, err := versionContext(ctx, d, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tables, err := c.ListTables(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := filterListed(d, listedTables(tables))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setListed(d, "tables", objects)
+}
+
+func listedTables(tables []Table) []listedObject {
+	objects := make([]listedObject, 0, len(tables))
+	for _, table := range tables {
+		objects = append(objects, listedObject{
+			ID:         table.ID,
+			Name:       table.Name,
+			Labels:     table.Labels,
+			Attributes: table.Attributes,
+		})
+	}
+	return objects
+}
@@ -1,73 +1,40 @@
 package anaml
 
 import (
-	"strconv"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func DataSourceUser() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceUserRead,
+		ReadContext: dataSourceUserRead,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"email": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"given_name": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"surname": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"roles": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-		},
+		// Users have no labels or attributes to select them by.
+		Schema: unionSchemas([]map[string]*schema.Schema{
+			dataSourceSchema(ResourceUser().Schema, "password"),
+			lookupSchema("user", "email", false),
+		}),
 	}
 }
 
-func dataSourceUserRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
-	email := d.Get("email").(string)
-
-	user, err := c.FindUserByEmail(email)
-	if err != nil {
-		return err
-	}
-
-	if user == nil {
-		d.SetId("")
-		return nil
-	}
-
-	d.SetId(strconv.Itoa(user.ID))
 
-	if err := d.Set("name", user.Name); err != nil {
-		return err
-	}
-	if err := d.Set("given_name", user.GivenName); err != nil {
-		return err
-	}
-	if err := d.Set("surname", user.Surname); err != nil {
-		return err
-	}
-	if err := d.Set("roles", mapRolesToFrontend(user.Roles)); err != nil {
-		return err
-	}
-	return nil
+	return objectLookup{
+		kind:    "user",
+		nameKey: "email",
+		find: func(ctx context.Context, email string) (*int, error) {
+			user, err := c.FindUserByEmail(ctx, email)
+			if err != nil || user == nil {
+				return nil, err
+			}
+			return &user.ID, nil
+		},
+		read: resourceUserRead,
+	}.readContext(ctx, d, m)
 }
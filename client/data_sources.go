@@ -1,13 +1,14 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 )
 
-func (c *Client) FindSource(sourceName string) (*Source, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/source", c.HostURL), nil)
+func (c *Client) FindSource(ctx context.Context, sourceName string) (*Source, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/source", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -34,8 +35,32 @@ func (c *Client) FindSource(sourceName string) (*Source, error) {
 	return &source, nil
 }
 
-func (c *Client) FindDestination(sourceName string) (*Destination, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/destination", c.HostURL), nil)
+func (c *Client) ListSources(ctx context.Context) ([]Source, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/source", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	sources := []Source{}
+	err = json.Unmarshal(body, &sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+func (c *Client) FindDestination(ctx context.Context, sourceName string) (*Destination, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/destination", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +87,32 @@ func (c *Client) FindDestination(sourceName string) (*Destination, error) {
 	return &destination, nil
 }
 
-func (c *Client) FindCluster(sourceName string) (*Cluster, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/cluster", c.HostURL), nil)
+func (c *Client) ListDestinations(ctx context.Context) ([]Destination, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/destination", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	destinations := []Destination{}
+	err = json.Unmarshal(body, &destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	return destinations, nil
+}
+
+func (c *Client) FindCluster(ctx context.Context, sourceName string) (*Cluster, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/cluster", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -89,3 +138,27 @@ func (c *Client) FindCluster(sourceName string) (*Cluster, error) {
 
 	return &cluster, nil
 }
+
+func (c *Client) ListClusters(ctx context.Context) ([]Cluster, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/cluster", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	clusters := []Cluster{}
+	err = json.Unmarshal(body, &clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetDestination(destinationID string) (*Destination, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), nil)
+func (c *Client) GetDestination(ctx context.Context, destinationID string) (*Destination, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetDestination(destinationID string) (*Destination, error) {
 	return &destination, nil
 }
 
-func (c *Client) CreateDestination(creationRequest Destination) (*Destination, error) {
+func (c *Client) CreateDestination(ctx context.Context, creationRequest Destination) (*Destination, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/destination", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/destination", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateDestination(creationRequest Destination) (*Destination, e
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateDestination(destinationID string, creationRequest Destination) error {
+func (c *Client) UpdateDestination(ctx context.Context, destinationID string, creationRequest Destination) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateDestination(destinationID string, creationRequest Destina
 	return nil
 }
 
-func (c *Client) DeleteDestination(destinationID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), nil)
+func (c *Client) DeleteDestination(ctx context.Context, destinationID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/destination/%s", c.HostURL, destinationID), nil)
 	if err != nil {
 		return err
 	}
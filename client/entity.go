@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetEntity(entityID string) (*Entity, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), nil)
+func (c *Client) GetEntity(ctx context.Context, entityID string) (*Entity, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetEntity(entityID string) (*Entity, error) {
 	return &entity, nil
 }
 
-func (c *Client) CreateEntity(creationRequest Entity) (*Entity, error) {
+func (c *Client) CreateEntity(ctx context.Context, creationRequest Entity) (*Entity, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/entity", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/entity", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateEntity(creationRequest Entity) (*Entity, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateEntity(entityID string, creationRequest Entity) error {
+func (c *Client) UpdateEntity(ctx context.Context, entityID string, creationRequest Entity) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateEntity(entityID string, creationRequest Entity) error {
 	return nil
 }
 
-func (c *Client) DeleteEntity(entityID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), nil)
+func (c *Client) DeleteEntity(ctx context.Context, entityID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/entity/%s", c.HostURL, entityID), nil)
 	if err != nil {
 		return err
 	}
@@ -89,8 +90,8 @@ func (c *Client) DeleteEntity(entityID string) error {
 	return nil
 }
 
-func (c *Client) FindEntityByName(name string) (*Entity, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/entity", c.HostURL), nil)
+func (c *Client) FindEntityByName(ctx context.Context, name string) (*Entity, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -116,3 +117,27 @@ func (c *Client) FindEntityByName(name string) (*Entity, error) {
 
 	return &item, nil
 }
+
+func (c *Client) ListEntities(ctx context.Context) ([]Entity, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []Entity{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetEntityMapping(entityID string) (*EntityMapping, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), nil)
+func (c *Client) GetEntityMapping(ctx context.Context, entityID string) (*EntityMapping, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetEntityMapping(entityID string) (*EntityMapping, error) {
 	return &entity, nil
 }
 
-func (c *Client) CreateEntityMapping(creationRequest EntityMapping) (*EntityMapping, error) {
+func (c *Client) CreateEntityMapping(ctx context.Context, creationRequest EntityMapping) (*EntityMapping, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/entity-mapping", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/entity-mapping", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateEntityMapping(creationRequest EntityMapping) (*EntityMapp
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateEntityMapping(entityID string, creationRequest EntityMapping) error {
+func (c *Client) UpdateEntityMapping(ctx context.Context, entityID string, creationRequest EntityMapping) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateEntityMapping(entityID string, creationRequest EntityMapp
 	return nil
 }
 
-func (c *Client) DeleteEntityMapping(entityID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), nil)
+func (c *Client) DeleteEntityMapping(ctx context.Context, entityID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/entity-mapping/%s", c.HostURL, entityID), nil)
 	if err != nil {
 		return err
 	}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetEntityPopulation(entityID string) (*EntityPopulation, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), nil)
+func (c *Client) GetEntityPopulation(ctx context.Context, entityID string) (*EntityPopulation, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,8 +31,8 @@ func (c *Client) GetEntityPopulation(entityID string) (*EntityPopulation, error)
 	return &population, nil
 }
 
-func (c *Client) FindEntityPopulationByName(sourceName string) (*EntityPopulation, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/entity-population", c.HostURL), nil)
+func (c *Client) FindEntityPopulationByName(ctx context.Context, sourceName string) (*EntityPopulation, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity-population", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -58,13 +59,37 @@ func (c *Client) FindEntityPopulationByName(sourceName string) (*EntityPopulatio
 	return &population, nil
 }
 
-func (c *Client) CreateEntityPopulation(creationRequest EntityPopulation) (*EntityPopulation, error) {
+func (c *Client) ListEntityPopulations(ctx context.Context) ([]EntityPopulation, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/entity-population", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []EntityPopulation{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *Client) CreateEntityPopulation(ctx context.Context, creationRequest EntityPopulation) (*EntityPopulation, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/entity-population", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/entity-population", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -84,13 +109,13 @@ func (c *Client) CreateEntityPopulation(creationRequest EntityPopulation) (*Enti
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateEntityPopulation(entityID string, creationRequest EntityPopulation) error {
+func (c *Client) UpdateEntityPopulation(ctx context.Context, entityID string, creationRequest EntityPopulation) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -103,8 +128,8 @@ func (c *Client) UpdateEntityPopulation(entityID string, creationRequest EntityP
 	return nil
 }
 
-func (c *Client) DeleteEntityPopulation(entityID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), nil)
+func (c *Client) DeleteEntityPopulation(ctx context.Context, entityID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/entity-population/%s", c.HostURL, entityID), nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,174 @@
+package anaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// EphemeralBranchConfig - Where writes are sent instead of the configured
+// branch, and the merge request which lands them on it.
+//
+// Branches starting with Prefix which have an open merge request into the
+// configured branch hold the writes of an earlier apply. The newest of them
+// is used in place of Name, so objects written by that apply can be read
+// until the merge request is merged, and later applies add to it rather
+// than opening competing merge requests.
+type EphemeralBranchConfig struct {
+	Name                    string
+	Prefix                  string
+	MergeRequestTitle       string
+	MergeRequestDescription string
+}
+
+// ephemeralBranch - Writes to the configured branch are redirected to a
+// branch of their own, created from it on the first write. A merge request
+// into the configured branch is opened at the same time, so it picks up
+// every later commit and is ready for review once the apply finishes.
+//
+// Reads go to the configured branch until the ephemeral branch exists, so
+// plans are made against the target branch, and objects written during an
+// apply can be read back afterwards. An ephemeral branch left open by an
+// earlier apply exists from the start, so reads go to it.
+type ephemeralBranch struct {
+	EphemeralBranchConfig
+	target string
+
+	mu       sync.Mutex
+	resolved bool
+	ready    bool
+}
+
+// UseEphemeralBranch - Sends writes to the configured branch to a new branch
+// instead, and opens a merge request for them.
+func (c *Client) UseEphemeralBranch(config EphemeralBranchConfig) error {
+	if c.Branch == nil {
+		return errors.New("A branch must be configured to write to an ephemeral branch")
+	}
+	if config.Name == "" || config.Name == *c.Branch {
+		return fmt.Errorf("Invalid ephemeral branch name %q", config.Name)
+	}
+
+	c.ephemeral = &ephemeralBranch{
+		EphemeralBranchConfig: config,
+		target:                *c.Branch,
+	}
+	return nil
+}
+
+// The branch a request for the given branch should be sent to.
+func (c *Client) ephemeralVersion(req *http.Request, branch string) (string, error) {
+	e := c.ephemeral
+	if e == nil || branch != e.target {
+		return branch, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.resolved {
+		if err := c.findPendingEphemeralBranch(withoutValues(req.Context()), e); err != nil {
+			return "", fmt.Errorf("Finding pending ephemeral branches: %w", err)
+		}
+		e.resolved = true
+	}
+
+	if e.ready {
+		return e.Name, nil
+	}
+	if isRead(req) {
+		return branch, nil
+	}
+
+	// The branch and merge request are made for the write, not as part of
+	// it, so they don't take its If-Match, commit message or recorder.
+	if err := c.openEphemeralBranch(withoutValues(req.Context()), e); err != nil {
+		return "", fmt.Errorf("Creating ephemeral branch %s: %w", e.Name, err)
+	}
+	e.ready = true
+	return e.Name, nil
+}
+
+// Adopts the newest ephemeral branch with an open merge request into the
+// target, which already has its branch and merge request.
+func (c *Client) findPendingEphemeralBranch(ctx context.Context, e *ephemeralBranch) error {
+	if e.Prefix == "" {
+		return nil
+	}
+
+	mergeRequests, err := c.ListMergeRequests(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending *MergeRequest
+	for i, mergeRequest := range mergeRequests {
+		if mergeRequest.TargetBranch != e.target || mergeRequest.Status != mergeRequestOpen || !strings.HasPrefix(mergeRequest.SourceBranch, e.Prefix) {
+			continue
+		}
+		if pending == nil || mergeRequest.ID > pending.ID {
+			pending = &mergeRequests[i]
+		}
+	}
+
+	if pending != nil {
+		log.Printf("[INFO] Using ephemeral branch %s, which merge request %d into %s is still open for", pending.SourceBranch, pending.ID, e.target)
+		e.Name = pending.SourceBranch
+		e.ready = true
+	}
+	return nil
+}
+
+// A branch left behind by an earlier apply with the same name is reused.
+func (c *Client) openEphemeralBranch(ctx context.Context, e *ephemeralBranch) error {
+	existing, err := c.GetBranch(ctx, e.Name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		log.Printf("[INFO] Writing to existing ephemeral branch %s", e.Name)
+	} else {
+		log.Printf("[INFO] Creating ephemeral branch %s from %s", e.Name, e.target)
+		target := e.target
+		_, err = c.CreateBranch(ctx, BranchCreationRequest{
+			Name: e.Name,
+			From: VersionTarget{Type: "branch", Branch: &target},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.openEphemeralMergeRequest(ctx, e)
+}
+
+// An earlier apply may have created the branch but failed to open its merge
+// request, so one is opened unless the branch already has one.
+func (c *Client) openEphemeralMergeRequest(ctx context.Context, e *ephemeralBranch) error {
+	existing, err := c.FindOpenMergeRequest(ctx, e.Name, e.target)
+	if err != nil {
+		return fmt.Errorf("finding merge request into %s: %w", e.target, err)
+	}
+	if existing != nil {
+		log.Printf("[INFO] Using open merge request %d from %s into %s", existing.ID, e.Name, e.target)
+		return nil
+	}
+
+	mergeRequest, err := c.CreateMergeRequest(ctx, MergeRequest{
+		SourceBranch: e.Name,
+		TargetBranch: e.target,
+		Title:        e.MergeRequestTitle,
+		Description:  e.MergeRequestDescription,
+	})
+	if err != nil {
+		return fmt.Errorf("opening merge request into %s: %w", e.target, err)
+	}
+
+	log.Printf("[INFO] Opened merge request %d from %s into %s", mergeRequest.ID, e.Name, e.target)
+	return nil
+}
@@ -0,0 +1,182 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordedRequest struct {
+	Method  string
+	Path    string
+	Query   string
+	IfMatch string
+}
+
+// A server for ephemeral branch tests, recording every request it receives.
+type ephemeralServer struct {
+	branchExists  bool
+	mergeRequests string
+
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+func (s *ephemeralServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, recordedRequest{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("If-Match")})
+	s.mu.Unlock()
+
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/branch/terraform-1":
+		if !s.branchExists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name":"terraform-1","head":{"id":"c1"}}`))
+	case r.Method == "POST" && r.URL.Path == "/branch":
+		w.Write([]byte(`{"name":"terraform-1","head":{"id":"c1"}}`))
+	case r.Method == "GET" && r.URL.Path == "/merge-request":
+		w.Write([]byte(s.mergeRequests))
+	case r.Method == "POST" && r.URL.Path == "/merge-request":
+		w.Write([]byte(`7`))
+	case r.Method == "PUT":
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *ephemeralServer) sent(method string, path string) []recordedRequest {
+	var matching []recordedRequest
+	for _, request := range s.requests {
+		if request.Method == method && request.Path == path {
+			matching = append(matching, request)
+		}
+	}
+	return matching
+}
+
+// Sends a write to the configured branch, with an If-Match and commit
+// message, through a client in ephemeral mode.
+func writeThroughEphemeralBranch(t *testing.T, server *ephemeralServer) {
+	c := newTestClient(t, server.ServeHTTP)
+	err := c.UseEphemeralBranch(EphemeralBranchConfig{Name: "terraform-1", MergeRequestTitle: "Terraform apply 1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithCommitMessage(WithIfMatch(context.Background(), "v1"), "update entity")
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/entity/1", c.HostURL), strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.doRequest(req); err != nil {
+		t.Fatal(err)
+	}
+
+	puts := server.sent("PUT", "/entity/1")
+	if len(puts) != 1 || puts[0].IfMatch != `"v1"` || !strings.Contains(puts[0].Query, "branch=terraform-1") {
+		t.Errorf("expected the write to go to the ephemeral branch with its If-Match, got %+v", puts)
+	}
+	for _, request := range server.requests {
+		if request.Path != "/entity/1" && (request.IfMatch != "" || strings.Contains(request.Query, "commitMessage")) {
+			t.Errorf("the write's values were sent with %+v", request)
+		}
+	}
+}
+
+func TestEphemeralBranchCreatesBranchAndMergeRequest(t *testing.T) {
+	server := &ephemeralServer{mergeRequests: `[]`}
+	writeThroughEphemeralBranch(t, server)
+
+	if len(server.sent("POST", "/branch")) != 1 {
+		t.Error("expected the ephemeral branch to be created")
+	}
+	if len(server.sent("POST", "/merge-request")) != 1 {
+		t.Error("expected a merge request to be opened")
+	}
+}
+
+func TestEphemeralBranchOpensMissingMergeRequest(t *testing.T) {
+	server := &ephemeralServer{
+		branchExists:  true,
+		mergeRequests: `[{"id":3,"sourceBranch":"terraform-1","targetBranch":"official","title":"","description":"","status":"closed"}]`,
+	}
+	writeThroughEphemeralBranch(t, server)
+
+	if len(server.sent("POST", "/branch")) != 0 {
+		t.Error("expected the existing branch to be reused")
+	}
+	if len(server.sent("POST", "/merge-request")) != 1 {
+		t.Error("expected a merge request to be opened for a branch without an open one")
+	}
+}
+
+func TestEphemeralBranchReusesOpenMergeRequest(t *testing.T) {
+	server := &ephemeralServer{
+		branchExists:  true,
+		mergeRequests: `[{"id":3,"sourceBranch":"terraform-1","targetBranch":"official","title":"","description":"","status":"open"}]`,
+	}
+	writeThroughEphemeralBranch(t, server)
+
+	if len(server.sent("POST", "/merge-request")) != 0 {
+		t.Error("expected the open merge request to be reused")
+	}
+}
+
+func TestEphemeralBranchAdoptsPendingBranch(t *testing.T) {
+	server := &ephemeralServer{
+		mergeRequests: `[
+			{"id":3,"sourceBranch":"terraform-0","targetBranch":"official","status":"open"},
+			{"id":4,"sourceBranch":"terraform-9","targetBranch":"official","status":"merged"},
+			{"id":5,"sourceBranch":"feature","targetBranch":"official","status":"open"},
+			{"id":6,"sourceBranch":"terraform-2","targetBranch":"other","status":"open"}
+		]`,
+	}
+	c := newTestClient(t, server.ServeHTTP)
+	err := c.UseEphemeralBranch(EphemeralBranchConfig{Name: "terraform-1", Prefix: "terraform-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetEntity(context.Background(), "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UpdateEntity(context.Background(), "1", Entity{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, method := range []string{"GET", "PUT"} {
+		requests := server.sent(method, "/entity/1")
+		if len(requests) != 1 || !strings.Contains(requests[0].Query, "branch=terraform-0") {
+			t.Errorf("expected the %s to go to the pending ephemeral branch, got %+v", method, requests)
+		}
+	}
+	if len(server.sent("POST", "/branch")) != 0 || len(server.sent("POST", "/merge-request")) != 0 {
+		t.Error("expected no new branch or merge request while one is pending")
+	}
+}
+
+func TestEphemeralBranchReadsTargetWithoutPendingBranch(t *testing.T) {
+	server := &ephemeralServer{
+		mergeRequests: `[{"id":3,"sourceBranch":"terraform-0","targetBranch":"official","status":"closed"}]`,
+	}
+	c := newTestClient(t, server.ServeHTTP)
+	err := c.UseEphemeralBranch(EphemeralBranchConfig{Name: "terraform-1", Prefix: "terraform-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetEntity(context.Background(), "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	gets := server.sent("GET", "/entity/1")
+	if len(gets) != 1 || !strings.Contains(gets[0].Query, "branch=official") {
+		t.Errorf("expected the read to go to the configured branch, got %+v", gets)
+	}
+}
@@ -0,0 +1,149 @@
+package anaml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError - A non-successful response from the Anaml API
+type APIError struct {
+	StatusCode  int
+	Message     string
+	FieldErrors []FieldError
+	Body        string
+}
+
+// FieldError - A validation failure Anaml attributes to a single field of
+// the submitted object. Field uses the API's (camelCase, dotted) naming.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" && len(e.FieldErrors) == 0 {
+		return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+	}
+
+	messages := make([]string, 0, len(e.FieldErrors)+1)
+	if e.Message != "" {
+		messages = append(messages, e.Message)
+	}
+	for _, fieldError := range e.FieldErrors {
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Message))
+	}
+	return fmt.Sprintf("status: %d, %s", e.StatusCode, strings.Join(messages, "; "))
+}
+
+// IsConflict - Whether err is a 409 from Anaml
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed - Whether err is a 412 from Anaml, returned when an
+// object has changed since the version a write was based on
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// IsForbidden - Whether err is a 403 from Anaml
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsUnauthorized - Whether err is a 401 from Anaml
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// The shape of Anaml's error responses has varied between versions, so we
+// accept a message under either "message" or "error", and field errors as
+// a list of objects, a list of plain messages, or a map of field to message.
+type errorPayload struct {
+	Message string          `json:"message"`
+	Error   string          `json:"error"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := APIError{
+		StatusCode: statusCode,
+		Body:       redactBody(body),
+	}
+
+	payload := errorPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		var message string
+		if err := json.Unmarshal(body, &message); err == nil {
+			apiErr.Message = message
+		}
+		return &apiErr
+	}
+
+	apiErr.Message = payload.Message
+	if apiErr.Message == "" {
+		apiErr.Message = payload.Error
+	}
+	apiErr.FieldErrors = parseFieldErrors(payload.Errors)
+
+	return &apiErr
+}
+
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var objects []FieldError
+	if err := json.Unmarshal(raw, &objects); err == nil {
+		return objects
+	}
+
+	var fieldErrors []FieldError
+
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		for _, message := range messages {
+			fieldErrors = append(fieldErrors, FieldError{Message: message})
+		}
+		return fieldErrors
+	}
+
+	var byField map[string]string
+	if err := json.Unmarshal(raw, &byField); err == nil {
+		for field, message := range byField {
+			fieldErrors = append(fieldErrors, FieldError{Field: field, Message: message})
+		}
+		sortFieldErrors(fieldErrors)
+		return fieldErrors
+	}
+
+	var manyByField map[string][]string
+	if err := json.Unmarshal(raw, &manyByField); err == nil {
+		for field, messages := range manyByField {
+			for _, message := range messages {
+				fieldErrors = append(fieldErrors, FieldError{Field: field, Message: message})
+			}
+		}
+		sortFieldErrors(fieldErrors)
+		return fieldErrors
+	}
+
+	return nil
+}
+
+// Maps have no order, but diagnostics should be reported consistently.
+func sortFieldErrors(fieldErrors []FieldError) {
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field < fieldErrors[j].Field
+	})
+}
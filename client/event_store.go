@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetEventStore(EventStoreId string) (*EventStore, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), nil)
+func (c *Client) GetEventStore(ctx context.Context, EventStoreId string) (*EventStore, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetEventStore(EventStoreId string) (*EventStore, error) {
 	return &entity, nil
 }
 
-func (c *Client) CreateEventStore(creationRequest EventStore) (*EventStore, error) {
+func (c *Client) CreateEventStore(ctx context.Context, creationRequest EventStore) (*EventStore, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/event-store", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/event-store", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateEventStore(creationRequest EventStore) (*EventStore, erro
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateEventStore(EventStoreId string, creationRequest EventStore) error {
+func (c *Client) UpdateEventStore(ctx context.Context, EventStoreId string, creationRequest EventStore) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateEventStore(EventStoreId string, creationRequest EventStor
 	return nil
 }
 
-func (c *Client) DeleteEventStore(EventStoreId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), nil)
+func (c *Client) DeleteEventStore(ctx context.Context, EventStoreId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/event-store/%s", c.HostURL, EventStoreId), nil)
 	if err != nil {
 		return err
 	}
@@ -89,8 +90,8 @@ func (c *Client) DeleteEventStore(EventStoreId string) error {
 	return nil
 }
 
-func (c *Client) FindEventStoreByName(name string) (*EventStore, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/event-store", c.HostURL), nil)
+func (c *Client) FindEventStoreByName(ctx context.Context, name string) (*EventStore, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/event-store", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,8 +9,8 @@ import (
 	"strings"
 )
 
-func (c *Client) GetFeature(featureID string) (*Feature, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), nil)
+func (c *Client) GetFeature(ctx context.Context, featureID string) (*Feature, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -32,8 +33,8 @@ func (c *Client) GetFeature(featureID string) (*Feature, error) {
 	return &feature, nil
 }
 
-func (c *Client) FindFeatureByName(featureName string) (*Feature, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature", c.HostURL), nil)
+func (c *Client) FindFeatureByName(ctx context.Context, featureName string) (*Feature, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,8 +61,32 @@ func (c *Client) FindFeatureByName(featureName string) (*Feature, error) {
 	return &item, nil
 }
 
-func (c *Client) FindFeatureByTemplate(templateId int, rows int, days int) (*Feature, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature", c.HostURL), nil)
+func (c *Client) ListFeatures(ctx context.Context) ([]Feature, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []Feature{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (c *Client) FindFeatureByTemplate(ctx context.Context, templateId int, rows int, days int) (*Feature, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -95,13 +120,13 @@ func (c *Client) FindFeatureByTemplate(templateId int, rows int, days int) (*Fea
 	return &feature, nil
 }
 
-func (c *Client) CreateFeature(creationRequest Feature) (*Feature, error) {
+func (c *Client) CreateFeature(ctx context.Context, creationRequest Feature) (*Feature, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/feature", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -121,13 +146,13 @@ func (c *Client) CreateFeature(creationRequest Feature) (*Feature, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateFeature(featureID string, creationRequest Feature) error {
+func (c *Client) UpdateFeature(ctx context.Context, featureID string, creationRequest Feature) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -140,8 +165,8 @@ func (c *Client) UpdateFeature(featureID string, creationRequest Feature) error
 	return nil
 }
 
-func (c *Client) DeleteFeature(featureID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), nil)
+func (c *Client) DeleteFeature(ctx context.Context, featureID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/feature/%s", c.HostURL, featureID), nil)
 	if err != nil {
 		return err
 	}
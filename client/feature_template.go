@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetFeatureTemplate(featureID string) (*FeatureTemplate, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-template/%s", c.HostURL, featureID), nil)
+func (c *Client) GetFeatureTemplate(ctx context.Context, featureID string) (*FeatureTemplate, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-template/%s", c.HostURL, featureID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,13 +32,13 @@ func (c *Client) GetFeatureTemplate(featureID string) (*FeatureTemplate, error)
 	return &feature, nil
 }
 
-func (c *Client) CreateFeatureTemplate(creationRequest FeatureTemplate) (*FeatureTemplate, error) {
+func (c *Client) CreateFeatureTemplate(ctx context.Context, creationRequest FeatureTemplate) (*FeatureTemplate, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-template", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/feature-template", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +58,13 @@ func (c *Client) CreateFeatureTemplate(creationRequest FeatureTemplate) (*Featur
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateFeatureTemplate(templateID string, creationRequest FeatureTemplate) error {
+func (c *Client) UpdateFeatureTemplate(ctx context.Context, templateID string, creationRequest FeatureTemplate) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature-template/%s", c.HostURL, templateID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/feature-template/%s", c.HostURL, templateID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -76,8 +77,8 @@ func (c *Client) UpdateFeatureTemplate(templateID string, creationRequest Featur
 	return nil
 }
 
-func (c *Client) DeleteFeatureTemplate(templateID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/feature-template/%s", c.HostURL, templateID), nil)
+func (c *Client) DeleteFeatureTemplate(ctx context.Context, templateID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/feature-template/%s", c.HostURL, templateID), nil)
 	if err != nil {
 		return err
 	}
@@ -90,8 +91,8 @@ func (c *Client) DeleteFeatureTemplate(templateID string) error {
 	return nil
 }
 
-func (c *Client) FindFeatureTemplateByName(name string) (*FeatureTemplate, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-template", c.HostURL), nil)
+func (c *Client) FindFeatureTemplateByName(ctx context.Context, name string) (*FeatureTemplate, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-template", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -117,3 +118,27 @@ func (c *Client) FindFeatureTemplateByName(name string) (*FeatureTemplate, error
 
 	return &item, nil
 }
+
+func (c *Client) ListFeatureTemplates(ctx context.Context) ([]FeatureTemplate, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-template", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []FeatureTemplate{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
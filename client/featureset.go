@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetFeatureSet(FeatureSetID string) (*FeatureSet, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), nil)
+func (c *Client) GetFeatureSet(ctx context.Context, FeatureSetID string) (*FeatureSet, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetFeatureSet(FeatureSetID string) (*FeatureSet, error) {
 	return &FeatureSet, nil
 }
 
-func (c *Client) CreateFeatureSet(creationRequest FeatureSet) (*FeatureSet, error) {
+func (c *Client) CreateFeatureSet(ctx context.Context, creationRequest FeatureSet) (*FeatureSet, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-set", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/feature-set", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateFeatureSet(creationRequest FeatureSet) (*FeatureSet, erro
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateFeatureSet(FeatureSetID string, creationRequest FeatureSet) error {
+func (c *Client) UpdateFeatureSet(ctx context.Context, FeatureSetID string, creationRequest FeatureSet) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateFeatureSet(FeatureSetID string, creationRequest FeatureSe
 	return nil
 }
 
-func (c *Client) DeleteFeatureSet(FeatureSetID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), nil)
+func (c *Client) DeleteFeatureSet(ctx context.Context, FeatureSetID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/feature-set/%s", c.HostURL, FeatureSetID), nil)
 	if err != nil {
 		return err
 	}
@@ -89,8 +90,8 @@ func (c *Client) DeleteFeatureSet(FeatureSetID string) error {
 	return nil
 }
 
-func (c *Client) FindFeatureSetByName(name string) (*FeatureSet, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-set", c.HostURL), nil)
+func (c *Client) FindFeatureSetByName(ctx context.Context, name string) (*FeatureSet, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-set", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -116,3 +117,27 @@ func (c *Client) FindFeatureSetByName(name string) (*FeatureSet, error) {
 
 	return &item, nil
 }
+
+func (c *Client) ListFeatureSets(ctx context.Context) ([]FeatureSet, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-set", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []FeatureSet{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetFeatureStore(FeatureStoreID string) (*FeatureStore, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), nil)
+func (c *Client) GetFeatureStore(ctx context.Context, FeatureStoreID string) (*FeatureStore, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetFeatureStore(FeatureStoreID string) (*FeatureStore, error) {
 	return &FeatureStore, nil
 }
 
-func (c *Client) CreateFeatureStore(creationRequest FeatureStore) (*FeatureStore, error) {
+func (c *Client) CreateFeatureStore(ctx context.Context, creationRequest FeatureStore) (*FeatureStore, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/feature-store", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/feature-store", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateFeatureStore(creationRequest FeatureStore) (*FeatureStore
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateFeatureStore(FeatureStoreID string, creationRequest FeatureStore) error {
+func (c *Client) UpdateFeatureStore(ctx context.Context, FeatureStoreID string, creationRequest FeatureStore) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateFeatureStore(FeatureStoreID string, creationRequest Featu
 	return nil
 }
 
-func (c *Client) DeleteFeatureStore(FeatureStoreID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), nil)
+func (c *Client) DeleteFeatureStore(ctx context.Context, FeatureStoreID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/feature-store/%s", c.HostURL, FeatureStoreID), nil)
 	if err != nil {
 		return err
 	}
@@ -89,8 +90,8 @@ func (c *Client) DeleteFeatureStore(FeatureStoreID string) error {
 	return nil
 }
 
-func (c *Client) FindFeatureStoreByName(name string) (*FeatureStore, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/feature-store", c.HostURL), nil)
+func (c *Client) FindFeatureStoreByName(ctx context.Context, name string) (*FeatureStore, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-store", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -116,3 +117,27 @@ func (c *Client) FindFeatureStoreByName(name string) (*FeatureStore, error) {
 
 	return &item, nil
 }
+
+func (c *Client) ListFeatureStores(ctx context.Context) ([]FeatureStore, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/feature-store", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []FeatureStore{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetLabelRestriction(labelID string) (*LabelRestriction, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), nil)
+func (c *Client) GetLabelRestriction(ctx context.Context, labelID string) (*LabelRestriction, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetLabelRestriction(labelID string) (*LabelRestriction, error)
 	return &label, nil
 }
 
-func (c *Client) CreateLabelRestriction(creationRequest LabelRestriction) (*LabelRestriction, error) {
+func (c *Client) CreateLabelRestriction(ctx context.Context, creationRequest LabelRestriction) (*LabelRestriction, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/allowed-label", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/allowed-label", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateLabelRestriction(creationRequest LabelRestriction) (*Labe
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateLabelRestriction(labelID string, creationRequest LabelRestriction) error {
+func (c *Client) UpdateLabelRestriction(ctx context.Context, labelID string, creationRequest LabelRestriction) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateLabelRestriction(labelID string, creationRequest LabelRes
 	return nil
 }
 
-func (c *Client) DeleteLabelRestriction(labelID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), nil)
+func (c *Client) DeleteLabelRestriction(ctx context.Context, labelID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/allowed-label/%s", c.HostURL, labelID), nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,120 @@
+package anaml
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// requestLimiter - Bounds how many requests are in flight at once and how
+// quickly new ones are started. Shared by every resource using the client.
+type requestLimiter struct {
+	slots chan struct{}
+
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+// ConfigureLimits - Limits concurrent requests and the request rate. Zero
+// disables the corresponding limit.
+func (c *Client) ConfigureLimits(maxConcurrentRequests int, requestsPerSecond float64) {
+	limiter := &requestLimiter{}
+
+	if maxConcurrentRequests > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	if requestsPerSecond > 0 {
+		limiter.rate = requestsPerSecond
+		limiter.burst = math.Max(1, math.Ceil(requestsPerSecond))
+		limiter.tokens = limiter.burst
+		limiter.lastFill = time.Now()
+	}
+
+	c.limiter = limiter
+}
+
+// Blocks until the request may be sent. The returned function must be
+// called once the response has been read.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if err := l.waitForToken(ctx); err != nil {
+		return nil, err
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *requestLimiter) waitForToken(ctx context.Context) error {
+	if l.rate == 0 {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.lastFill).Seconds()*l.rate)
+		l.lastFill = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// branchLocks - Every write to a branch creates a commit on it, and Anaml
+// rejects commits racing for the same branch head, so writes to each branch
+// are made one at a time.
+type branchLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Takes the lock for the branch a write request targets. Reads, and writes
+// to objects which don't live on a branch, aren't serialised.
+func (c *Client) lockBranch(req *http.Request) func() {
+	branch := req.URL.Query().Get("branch")
+	if isRead(req) || branch == "" {
+		return func() {}
+	}
+
+	c.branchLocks.mu.Lock()
+	if c.branchLocks.locks == nil {
+		c.branchLocks.locks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := c.branchLocks.locks[branch]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.branchLocks.locks[branch] = lock
+	}
+	c.branchLocks.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
@@ -0,0 +1,139 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Counts the requests the handler is serving at once, per branch.
+type inFlight struct {
+	mu      sync.Mutex
+	current map[string]int
+	max     map[string]int
+}
+
+func (f *inFlight) start(branch string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current[branch]++
+	if f.current[branch] > f.max[branch] {
+		f.max[branch] = f.current[branch]
+	}
+}
+
+func (f *inFlight) done(branch string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current[branch]--
+}
+
+func TestWritesToOneBranchAreSerialised(t *testing.T) {
+	flight := &inFlight{current: map[string]int{}, max: map[string]int{}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		branch := r.URL.Query().Get("branch")
+		flight.start(branch)
+		defer flight.done(branch)
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	ctx := WithBranch(context.Background(), "official")
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.UpdateFeature(ctx, "1", Feature{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if flight.max["official"] != 1 {
+		t.Errorf("expected one write to the branch at a time, got %d", flight.max["official"])
+	}
+}
+
+func TestWritesToOtherBranchesAreNotBlocked(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("branch") == "blocked" {
+			close(started)
+			<-release
+		}
+	})
+	c.HTTPClient.Timeout = 5 * time.Second
+
+	blocked := make(chan error)
+	go func() {
+		blocked <- c.UpdateFeature(WithBranch(context.Background(), "blocked"), "1", Feature{})
+	}()
+	<-started
+
+	written := make(chan error)
+	go func() {
+		written <- c.UpdateFeature(WithBranch(context.Background(), "other"), "1", Feature{})
+	}()
+
+	select {
+	case err := <-written:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected a write to another branch not to wait for the blocked branch")
+	}
+
+	close(release)
+	if err := <-blocked; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLimiterCapsRequestsInFlight(t *testing.T) {
+	flight := &inFlight{current: map[string]int{}, max: map[string]int{}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		flight.start("")
+		defer flight.done("")
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"id":1}`))
+	})
+	c.ConfigureLimits(2, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetFeature(context.Background(), "1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if flight.max[""] > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", flight.max[""])
+	}
+}
+
+func TestLimiterGivesUpWhenCancelled(t *testing.T) {
+	c := &Client{}
+	c.ConfigureLimits(1, 0)
+
+	release, err := c.limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.limiter.acquire(ctx); err == nil {
+		t.Error("expected waiting for a free slot to stop when the context is done")
+	}
+}
@@ -0,0 +1,85 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetLineage - The lineage graph around an object, such as a "table" or a
+// "feature-set"
+func (c *Client) GetLineage(ctx context.Context, kind string, id string) (*Lineage, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/lineage/%s/%s", c.HostURL, kind, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	lineage := Lineage{}
+	err = json.Unmarshal(body, &lineage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lineage, nil
+}
+
+// LineageDistance - An object in a lineage graph, and how many edges away it
+// is from the object the graph was walked from
+type LineageDistance struct {
+	LineageNode
+	Distance int
+}
+
+// Upstream - The objects whose data flows into the given one, nearest first,
+// up to depth edges away. A depth of 0 has no limit.
+func (l *Lineage) Upstream(from LineageNode, depth int) []LineageDistance {
+	next := make(map[LineageNode][]LineageNode)
+	for _, edge := range l.Edges {
+		next[edge.To] = append(next[edge.To], edge.From)
+	}
+	return walkLineage(next, from, depth)
+}
+
+// Downstream - The objects the given one's data flows into, nearest first,
+// up to depth edges away. A depth of 0 has no limit.
+func (l *Lineage) Downstream(from LineageNode, depth int) []LineageDistance {
+	next := make(map[LineageNode][]LineageNode)
+	for _, edge := range l.Edges {
+		next[edge.From] = append(next[edge.From], edge.To)
+	}
+	return walkLineage(next, from, depth)
+}
+
+// A breadth first walk, so each object is reported at its shortest distance.
+func walkLineage(next map[LineageNode][]LineageNode, from LineageNode, depth int) []LineageDistance {
+	seen := map[LineageNode]bool{from: true}
+	frontier := []LineageNode{from}
+	res := []LineageDistance{}
+
+	for distance := 1; len(frontier) > 0 && (depth == 0 || distance <= depth); distance++ {
+		var reached []LineageNode
+		for _, node := range frontier {
+			for _, other := range next[node] {
+				if seen[other] {
+					continue
+				}
+				seen[other] = true
+				reached = append(reached, other)
+				res = append(res, LineageDistance{LineageNode: other, Distance: distance})
+			}
+		}
+		frontier = reached
+	}
+
+	return res
+}
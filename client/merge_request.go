@@ -0,0 +1,131 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (c *Client) GetMergeRequest(ctx context.Context, mergeRequestId string) (*MergeRequest, error) {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "GET", fmt.Sprintf("%s/merge-request/%s", c.HostURL, mergeRequestId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	mergeRequest := MergeRequest{}
+	err = json.Unmarshal(body, &mergeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeRequest, nil
+}
+
+func (c *Client) ListMergeRequests(ctx context.Context) ([]MergeRequest, error) {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "GET", fmt.Sprintf("%s/merge-request", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	mergeRequests := []MergeRequest{}
+	err = json.Unmarshal(body, &mergeRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeRequests, nil
+}
+
+// FindOpenMergeRequest - The open merge request from one branch into
+// another, if there is one.
+func (c *Client) FindOpenMergeRequest(ctx context.Context, sourceBranch string, targetBranch string) (*MergeRequest, error) {
+	mergeRequests, err := c.ListMergeRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mergeRequest := range mergeRequests {
+		if mergeRequest.SourceBranch == sourceBranch && mergeRequest.TargetBranch == targetBranch && mergeRequest.Status == mergeRequestOpen {
+			return &mergeRequest, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) CreateMergeRequest(ctx context.Context, creationRequest MergeRequest) (*MergeRequest, error) {
+	rb, err := json.Marshal(creationRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "POST", fmt.Sprintf("%s/merge-request", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var V int
+	err = json.Unmarshal(body, &V)
+	if err != nil {
+		return nil, err
+	}
+
+	creationRequest.ID = V
+	return &creationRequest, nil
+}
+
+func (c *Client) UpdateMergeRequest(ctx context.Context, mergeRequestId string, mergeRequest MergeRequest) error {
+	rb, err := json.Marshal(mergeRequest)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "PUT", fmt.Sprintf("%s/merge-request/%s", c.HostURL, mergeRequestId), strings.NewReader(string(rb)))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CloseMergeRequest - Closes a merge request without merging it
+func (c *Client) CloseMergeRequest(ctx context.Context, mergeRequestId string) error {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "POST", fmt.Sprintf("%s/merge-request/%s/close", c.HostURL, mergeRequestId), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
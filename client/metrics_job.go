@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetMetricsJob(MetricsJobID string) (*MetricsJob, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/metrics-job/%s", c.HostURL, MetricsJobID), nil)
+func (c *Client) GetMetricsJob(ctx context.Context, MetricsJobID string) (*MetricsJob, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/metrics-job/%s", c.HostURL, MetricsJobID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetMetricsJob(MetricsJobID string) (*MetricsJob, error) {
 	return &MetricsJob, nil
 }
 
-func (c *Client) CreateMetricsJob(creationRequest MetricsJob) (*MetricsJob, error) {
+func (c *Client) CreateMetricsJob(ctx context.Context, creationRequest MetricsJob) (*MetricsJob, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/metrics-job", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/metrics-job", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateMetricsJob(creationRequest MetricsJob) (*MetricsJob, erro
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateMetricsJob(MetricsJobID string, creationRequest MetricsJob) error {
+func (c *Client) UpdateMetricsJob(ctx context.Context, MetricsJobID string, creationRequest MetricsJob) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/metrics-job/%s", c.HostURL, MetricsJobID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/metrics-job/%s", c.HostURL, MetricsJobID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateMetricsJob(MetricsJobID string, creationRequest MetricsJo
 	return nil
 }
 
-func (c *Client) DeleteMetricsJob(MetricsJobID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/metrics-job/%s", c.HostURL, MetricsJobID), nil)
+func (c *Client) DeleteMetricsJob(ctx context.Context, MetricsJobID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/metrics-job/%s", c.HostURL, MetricsJobID), nil)
 	if err != nil {
 		return err
 	}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetMetricsSet(MetricsSetID string) (*MetricsSet, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/metrics-set/%s", c.HostURL, MetricsSetID), nil)
+func (c *Client) GetMetricsSet(ctx context.Context, MetricsSetID string) (*MetricsSet, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/metrics-set/%s", c.HostURL, MetricsSetID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetMetricsSet(MetricsSetID string) (*MetricsSet, error) {
 	return &MetricsSet, nil
 }
 
-func (c *Client) CreateMetricsSet(creationRequest MetricsSet) (*MetricsSet, error) {
+func (c *Client) CreateMetricsSet(ctx context.Context, creationRequest MetricsSet) (*MetricsSet, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/metrics-set", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/metrics-set", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateMetricsSet(creationRequest MetricsSet) (*MetricsSet, erro
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateMetricsSet(MetricsSetID string, creationRequest MetricsSet) error {
+func (c *Client) UpdateMetricsSet(ctx context.Context, MetricsSetID string, creationRequest MetricsSet) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/metrics-set/%s", c.HostURL, MetricsSetID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/metrics-set/%s", c.HostURL, MetricsSetID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateMetricsSet(MetricsSetID string, creationRequest MetricsSe
 	return nil
 }
 
-func (c *Client) DeleteMetricsSet(MetricsSetID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/metrics-set/%s", c.HostURL, MetricsSetID), nil)
+func (c *Client) DeleteMetricsSet(ctx context.Context, MetricsSetID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/metrics-set/%s", c.HostURL, MetricsSetID), nil)
 	if err != nil {
 		return err
 	}
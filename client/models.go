@@ -1,5 +1,7 @@
 package anaml
 
+import "encoding/json"
+
 type AnamlObject struct {
 	ID   int    `json:"id"`
 	Type string `json:"adt_type"`
@@ -8,6 +10,7 @@ type AnamlObject struct {
 // Entity ..
 type Entity struct {
 	ID            int          `json:"id,omitempty"`
+	Version       string       `json:"version,omitempty"`
 	Name          string       `json:"name"`
 	Description   string       `json:"description"`
 	Type          string       `json:"adt_type"`
@@ -20,16 +23,18 @@ type Entity struct {
 
 // EntityMapping ..
 type EntityMapping struct {
-	ID        int   `json:"id,omitempty"`
-	From      int   `json:"from"`
-	To        int   `json:"to"`
-	Mapping   int   `json:"mapping"`
-	OneToMany *bool `json:"oneToMany,omitempty"`
+	ID        int    `json:"id,omitempty"`
+	Version   string `json:"version,omitempty"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Mapping   int    `json:"mapping"`
+	OneToMany *bool  `json:"oneToMany,omitempty"`
 }
 
 // EntityPopulation ..
 type EntityPopulation struct {
 	ID          int         `json:"id,omitempty"`
+	Version     string      `json:"version,omitempty"`
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	Labels      []string    `json:"labels"`
@@ -101,6 +106,7 @@ type ColumnInfo struct {
 // Table ...
 type Table struct {
 	ID            int                   `json:"id,omitempty"`
+	Version       string                `json:"version,omitempty"`
 	Name          string                `json:"name"`
 	Description   string                `json:"description"`
 	Type          string                `json:"adt_type"`
@@ -143,6 +149,7 @@ type AggregateExpression struct {
 // a really dumb `null` where it doesn't make sense to do so.
 type Feature struct {
 	ID          int                  `json:"id,omitempty"`
+	Version     string               `json:"version,omitempty"`
 	Name        string               `json:"name"`
 	Description string               `json:"description"`
 	Type        string               `json:"adt_type"`
@@ -164,6 +171,7 @@ type Feature struct {
 // FeatureTemplate ... again, completely normalised.
 type FeatureTemplate struct {
 	ID          int                  `json:"id,omitempty"`
+	Version     string               `json:"version,omitempty"`
 	Name        string               `json:"name"`
 	Description string               `json:"description"`
 	Type        string               `json:"adt_type"`
@@ -183,6 +191,7 @@ type FeatureTemplate struct {
 // FeatureSet ...
 type FeatureSet struct {
 	ID          int         `json:"id,omitempty"`
+	Version     string      `json:"version,omitempty"`
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	EntityID    int         `json:"entity,omitempty"`
@@ -226,6 +235,7 @@ type Metric struct {
 // MetricsSet ...
 type MetricsSet struct {
 	ID          int           `json:"id,omitempty"`
+	Version     string        `json:"version,omitempty"`
 	Name        string        `json:"name"`
 	Description string        `json:"description"`
 	Labels      []string      `json:"labels"`
@@ -242,9 +252,47 @@ type VersionTarget struct {
 	Branch *string `json:"branchName,omitempty"`
 }
 
+// Commit ...
+type Commit struct {
+	ID        string   `json:"id"`
+	Parents   []string `json:"parents,omitempty"`
+	CreatedAt string   `json:"createdAt,omitempty"`
+}
+
+// Branch ...
+type Branch struct {
+	Name string `json:"name"`
+	Head Commit `json:"head"`
+}
+
+// BranchCreationRequest ...
+type BranchCreationRequest struct {
+	Name string        `json:"name"`
+	From VersionTarget `json:"from"`
+}
+
+// MergeRequest ...
+type MergeRequest struct {
+	ID           int                    `json:"id,omitempty"`
+	SourceBranch string                 `json:"sourceBranch"`
+	TargetBranch string                 `json:"targetBranch"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	Status       string                 `json:"status,omitempty"`
+	Approvals    []MergeRequestApproval `json:"approvals,omitempty"`
+	MergeCommit  *string                `json:"mergeCommit,omitempty"`
+}
+
+// MergeRequestApproval ...
+type MergeRequestApproval struct {
+	UserID    int    `json:"userId"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
 // FeatureStore ...
 type FeatureStore struct {
 	ID                        int                    `json:"id,omitempty"`
+	Version                   string                 `json:"version,omitempty"`
 	Type                      string                 `json:"adt_type"`
 	Name                      string                 `json:"name"`
 	Description               string                 `json:"description"`
@@ -270,6 +318,7 @@ type FeatureStore struct {
 // MetricsJob ...
 type MetricsJob struct {
 	ID                  int                    `json:"id,omitempty"`
+	Version             string                 `json:"version,omitempty"`
 	Name                string                 `json:"name"`
 	Description         string                 `json:"description"`
 	Labels              []string               `json:"labels"`
@@ -305,7 +354,7 @@ type SensitiveAttribute struct {
 
 type SecretValueConfig struct {
 	Type          string `json:"adt_type"`
-	Secret        string `json:"secret,omitempty"`
+	Secret        string `json:"secret,omitempty" sensitive:"true"`
 	FilePath      string `json:"filepath,omitempty"`
 	SecretProject string `json:"secretProject,omitempty"`
 	SecretId      string `json:"secretId,omitempty"`
@@ -319,6 +368,7 @@ type ViewMaterialisationSpec struct {
 // ViewMaterialisation ...
 type ViewMaterialisationJob struct {
 	ID                        int                       `json:"id,omitempty"`
+	Version                   string                    `json:"version,omitempty"`
 	Type                      string                    `json:"adt_type"`
 	Name                      string                    `json:"name"`
 	Description               string                    `json:"description"`
@@ -338,6 +388,7 @@ type ViewMaterialisationJob struct {
 // Source ...
 type Source struct {
 	ID                  int                             `json:"id,omitempty"`
+	Version             string                          `json:"version,omitempty"`
 	Name                string                          `json:"name"`
 	Description         string                          `json:"description"`
 	Type                string                          `json:"adt_type"`
@@ -345,8 +396,8 @@ type Source struct {
 	Path                string                          `json:"path,omitempty"`
 	FileFormat          *FileFormat                     `json:"fileFormat,omitempty"`
 	Endpoint            string                          `json:"endpoint,omitempty"`
-	AccessKey           string                          `json:"accessKey,omitempty"`
-	SecretKey           string                          `json:"secretKey,omitempty"`
+	AccessKey           string                          `json:"accessKey,omitempty" sensitive:"true"`
+	SecretKey           string                          `json:"secretKey,omitempty" sensitive:"true"`
 	URL                 string                          `json:"url,omitempty"`
 	Schema              string                          `json:"schema,omitempty"`
 	CredentialsProvider *LoginCredentialsProviderConfig `json:"credentialsProvider,omitempty"`
@@ -409,6 +460,7 @@ type MaskingRule struct {
 // Destination ...
 type Destination struct {
 	ID                  int                             `json:"id,omitempty"`
+	Version             string                          `json:"version,omitempty"`
 	Name                string                          `json:"name"`
 	Description         string                          `json:"description"`
 	Labels              []string                        `json:"labels"`
@@ -418,8 +470,8 @@ type Destination struct {
 	Path                string                          `json:"path,omitempty"`
 	FileFormat          *FileFormat                     `json:"fileFormat,omitempty"`
 	Endpoint            string                          `json:"endpoint,omitempty"`
-	AccessKey           string                          `json:"accessKey,omitempty"`
-	SecretKey           string                          `json:"secretKey,omitempty"`
+	AccessKey           string                          `json:"accessKey,omitempty" sensitive:"true"`
+	SecretKey           string                          `json:"secretKey,omitempty" sensitive:"true"`
 	URL                 string                          `json:"url,omitempty"`
 	Schema              string                          `json:"schema,omitempty"`
 	CredentialsProvider *LoginCredentialsProviderConfig `json:"credentialsProvider,omitempty"`
@@ -456,6 +508,7 @@ type DestinationReference struct {
 // Cluster ...
 type Cluster struct {
 	ID                  int                             `json:"id,omitempty"`
+	Version             string                          `json:"version,omitempty"`
 	Name                string                          `json:"name"`
 	Description         string                          `json:"description"`
 	Type                string                          `json:"adt_type"`
@@ -473,7 +526,7 @@ type Cluster struct {
 type LoginCredentialsProviderConfig struct {
 	Type                  string `json:"adt_type"`
 	Username              string `json:"username"`
-	Password              string `json:"password,omitempty"`
+	Password              string `json:"password,omitempty" sensitive:"true"`
 	FilePath              string `json:"filepath,omitempty"`
 	PasswordSecretProject string `json:"passwordSecretProject,omitempty"`
 	PasswordSecretId      string `json:"passwordSecretId,omitempty"`
@@ -500,25 +553,115 @@ type Role struct {
 
 type User struct {
 	ID        int     `json:"id,omitempty"`
+	Version   string  `json:"version,omitempty"`
 	Name      string  `json:"name"`
 	Email     *string `json:"email,omitempty"`
 	GivenName *string `json:"givenName,omitempty"`
 	Surname   *string `json:"surname,omitempty"`
-	Password  *string `json:"password,omitempty"`
+	Password  *string `json:"password,omitempty" sensitive:"true"`
 	Roles     []Role  `json:"roles"`
 }
 
+// ServerInfo - The version of the Anaml server
+type ServerInfo struct {
+	Version string `json:"version"`
+}
+
+// TableSchema - The columns Anaml infers for a table, or for the data at a
+// source location
+type TableSchema struct {
+	Columns []SchemaColumn `json:"columns"`
+}
+
+// SchemaColumn - A column's name, and its Spark type, such as "string",
+// "timestamp" or "array<bigint>"
+type SchemaColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"dataType"`
+	Nullable bool   `json:"nullable"`
+}
+
+// FeaturePreviewRequest - A feature to compute on a preview cluster for a
+// single date, either an existing one by id or one given in full
+type FeaturePreviewRequest struct {
+	FeatureID *int     `json:"featureId,omitempty"`
+	Feature   *Feature `json:"feature,omitempty"`
+	Cluster   int      `json:"cluster"`
+	Date      string   `json:"date"`
+	Limit     int      `json:"limit,omitempty"`
+}
+
+// FeaturePreview - A sample of a feature's values, and statistics over all
+// of them
+type FeaturePreview struct {
+	Rows    []FeaturePreviewRow `json:"rows"`
+	Summary FeatureSummary      `json:"summary"`
+}
+
+// FeaturePreviewRow - A feature's value for one entity. Both are whatever
+// JSON type the entity's and feature's Spark types map to.
+type FeaturePreviewRow struct {
+	Entity json.RawMessage `json:"entity"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// FeatureSummary - Statistics over a feature's values. The numeric ones are
+// only given for numeric features.
+type FeatureSummary struct {
+	Count         int      `json:"count"`
+	NullCount     int      `json:"nullCount"`
+	DistinctCount int      `json:"distinctCount"`
+	Min           *float64 `json:"min,omitempty"`
+	Max           *float64 `json:"max,omitempty"`
+	Mean          *float64 `json:"mean,omitempty"`
+}
+
+// TablePreviewRequest - A table to compute on a preview cluster for a single
+// date
+type TablePreviewRequest struct {
+	Table   Table  `json:"table"`
+	Cluster int    `json:"cluster"`
+	Date    string `json:"date"`
+	Limit   int    `json:"limit,omitempty"`
+}
+
+// TablePreview - A table's columns, and a sample of its rows
+type TablePreview struct {
+	Columns []SchemaColumn    `json:"columns"`
+	Rows    []json.RawMessage `json:"rows"`
+}
+
+// LineageNode - An object in Anaml's lineage graph, such as a "table" or a
+// "feature-set"
+type LineageNode struct {
+	Type string `json:"adt_type"`
+	ID   int    `json:"id"`
+}
+
+// LineageEdge - Data flowing from one object into another
+type LineageEdge struct {
+	From LineageNode `json:"from"`
+	To   LineageNode `json:"to"`
+}
+
+// Lineage - The objects connected to an object, and the flow of data
+// between them
+type Lineage struct {
+	Nodes []LineageNode `json:"nodes"`
+	Edges []LineageEdge `json:"edges"`
+}
+
 // Access token and creation request.
 type AccessToken struct {
 	ID          string `json:"id,omitempty"`
-	Secret      string `json:"secret,omitempty"`
+	Secret      string `json:"secret,omitempty" sensitive:"true"`
 	Owner       *int   `json:"owner,omitempty"`
 	Description string `json:"description,omitempty"`
 	Roles       []Role `json:"roles"`
 }
 
 type ChangeOtherPasswordRequest struct {
-	Password *string `json:"password"`
+	Password *string `json:"password" sensitive:"true"`
 }
 
 type UserGroupMemberSource struct {
@@ -533,6 +676,7 @@ type UserGroupMember struct {
 // UserGroup ..
 type UserGroup struct {
 	ID              int               `json:"id,omitempty"`
+	Version         string            `json:"version,omitempty"`
 	Name            string            `json:"name"`
 	Description     string            `json:"description"`
 	Roles           []Role            `json:"roles"`
@@ -543,6 +687,7 @@ type UserGroup struct {
 // BranchProtection
 type BranchProtection struct {
 	ID                  int            `json:"id,omitempty"`
+	Version             string         `json:"version,omitempty"`
 	ProtectionPattern   string         `json:"protectionPattern"`
 	MergeApprovalRules  []ApprovalRule `json:"mergeApprovalRules"`
 	PushWhitelist       []PrincipalId  `json:"pushWhitelist"`
@@ -573,6 +718,7 @@ type MonitoringPlan struct {
 // TableMonitoring ...
 type TableMonitoring struct {
 	ID                  int             `json:"id,omitempty"`
+	Version             string          `json:"version,omitempty"`
 	Name                string          `json:"name"`
 	Description         string          `json:"description"`
 	Plan                *MonitoringPlan `json:"plan"`
@@ -592,6 +738,7 @@ type CachingPlan struct {
 // TableCaching ...
 type TableCaching struct {
 	ID                  int          `json:"id,omitempty"`
+	Version             string       `json:"version,omitempty"`
 	Name                string       `json:"name"`
 	Description         string       `json:"description"`
 	Principal           *int         `json:"principal,omitempty"`
@@ -610,6 +757,7 @@ type TableCachingSpec struct {
 
 type Webhook struct {
 	ID                   int       `json:"id,omitempty"`
+	Version              string    `json:"version,omitempty"`
 	Name                 string    `json:"name"`
 	Description          string    `json:"description"`
 	URL                  string    `json:"url"`
@@ -630,15 +778,17 @@ type Attribute struct {
 
 // LabelRestriction ...
 type LabelRestriction struct {
-	ID     int     `json:"id,omitempty"`
-	Text   string  `json:"text"`
-	Emoji  *string `json:"emoji,omitempty"`
-	Colour *string `json:"colour,omitempty"`
+	ID      int     `json:"id,omitempty"`
+	Version string  `json:"version,omitempty"`
+	Text    string  `json:"text"`
+	Emoji   *string `json:"emoji,omitempty"`
+	Colour  *string `json:"colour,omitempty"`
 }
 
 // AttributeRestriction ...
 type AttributeRestriction struct {
 	ID           int                    `json:"id,omitempty"`
+	Version      string                 `json:"version,omitempty"`
 	Key          string                 `json:"key"`
 	Description  string                 `json:"description"`
 	Type         string                 `json:"adt_type"`
@@ -670,6 +820,7 @@ type EventStoreTopicColumns struct {
 
 type EventStore struct {
 	ID                  int                               `json:"id,omitempty"`
+	Version             string                            `json:"version,omitempty"`
 	Name                string                            `json:"name"`
 	Description         string                            `json:"description"`
 	Labels              []string                          `json:"labels"`
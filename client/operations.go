@@ -0,0 +1,66 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// OperationsResources - Resources for objects which belong to the whole
+// deployment rather than to a branch, keyed by type name without the
+// provider's prefix.
+func OperationsResources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"access_token":             ResourceAccessToken(),
+		"attribute_restriction":    ResourceAttributeRestriction(),
+		"branch_protection":        ResourceBranchProtection(),
+		"caching":                  ResourceTableCaching(),
+		"cluster":                  ResourceCluster(),
+		"destination":              ResourceDestination(),
+		"event_store":              ResourceEventStore(),
+		"feature_store":            ResourceFeatureStore(),
+		"metrics_job":              ResourceMetricsJob(),
+		"label_restriction":        ResourceLabelRestriction(),
+		"monitoring":               ResourceTableMonitoring(),
+		"source":                   ResourceSource(),
+		"user_group":               ResourceUserGroup(),
+		"user":                     ResourceUser(),
+		"view_materialisation_job": ResourceViewMaterialisationJob(),
+		"webhook":                  ResourceWebhook(),
+	}
+}
+
+// OperationsDataSources - Data sources for objects which belong to the whole
+// deployment, keyed by type name without the provider's prefix.
+func OperationsDataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"cluster":       DataSourceCluster(),
+		"clusters":      DataSourceClusters(),
+		"destination":   DataSourceDestination(),
+		"destinations":  DataSourceDestinations(),
+		"source":        DataSourceSource(),
+		"sources":       DataSourceSources(),
+		"feature_store": DataSourceFeatureStore(),
+		"user":          DataSourceUser(),
+	}
+}
+
+// WithoutBranch - Sends every request made for the resource without a
+// branch, so it can be served by a provider which has one configured.
+func WithoutBranch(r *schema.Resource) *schema.Resource {
+	r.CreateContext = withoutBranchFunc(r.CreateContext)
+	r.ReadContext = withoutBranchFunc(r.ReadContext)
+	r.UpdateContext = withoutBranchFunc(r.UpdateContext)
+	r.DeleteContext = withoutBranchFunc(r.DeleteContext)
+	return r
+}
+
+func withoutBranchFunc(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return f(withoutVersion(ctx), d, m)
+	}
+}
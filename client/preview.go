@@ -0,0 +1,105 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PreviewFeature - Computes a feature on a preview cluster. Nothing is
+// written, so the request is never sent to an ephemeral branch.
+func (c *Client) PreviewFeature(ctx context.Context, previewRequest FeaturePreviewRequest) (*FeaturePreview, error) {
+	rb, err := json.Marshal(previewRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(readOnly(ctx), "POST", fmt.Sprintf("%s/feature/preview", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	preview := FeaturePreview{}
+	err = json.Unmarshal(body, &preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preview, nil
+}
+
+// PreviewTable - Computes a table on a preview cluster. Nothing is written,
+// so the request is never sent to an ephemeral branch.
+func (c *Client) PreviewTable(ctx context.Context, previewRequest TablePreviewRequest) (*TablePreview, error) {
+	rb, err := json.Marshal(previewRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(readOnly(ctx), "POST", fmt.Sprintf("%s/table/preview", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	preview := TablePreview{}
+	err = json.Unmarshal(body, &preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preview, nil
+}
+
+// previewCluster - The configured preview cluster, or else the only cluster
+// marked as one.
+func (c *Client) previewCluster(ctx context.Context) (int, error) {
+	if c.PreviewCluster != nil {
+		return *c.PreviewCluster, nil
+	}
+
+	clusters, err := c.ListClusters(withoutVersion(ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	var previewClusters []Cluster
+	for _, cluster := range clusters {
+		if cluster.IsPreviewCluster {
+			previewClusters = append(previewClusters, cluster)
+		}
+	}
+
+	switch len(previewClusters) {
+	case 1:
+		return previewClusters[0].ID, nil
+	case 0:
+		return 0, errors.New("No cluster in Anaml is a preview cluster. Set is_preview_cluster on one, or set the provider's preview_cluster")
+	default:
+		names := make([]string, 0, len(previewClusters))
+		for _, cluster := range previewClusters {
+			names = append(names, cluster.Name)
+		}
+		return 0, fmt.Errorf("There is more than one preview cluster (%s). Set the provider's preview_cluster to choose one", strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,300 @@
+package anaml
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// CommonProviderSchema - Settings shared by the anaml and anaml-operations
+// providers, for connecting to Anaml and the defaults applied to objects.
+func CommonProviderSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_HOST", nil),
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_USERNAME", nil),
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_PASSWORD", nil),
+		},
+		"login": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Exchange the username and password for a session token once, rather than sending them with every request",
+		},
+		"access_token_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The id of an access token to authenticate with",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_ACCESS_TOKEN_ID", nil),
+		},
+		"access_token_secret": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The secret of an access token to authenticate with",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_ACCESS_TOKEN_SECRET", nil),
+		},
+		"token": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "A bearer token to authenticate with",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_TOKEN", nil),
+		},
+		"default_labels": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Labels added to every object with labels",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+		"default_attributes": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Attributes added to every object with attributes. Attributes set on an object take precedence",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"skip_credentials_validation": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Don't check the host, credentials and branch when the provider is configured, such as when Anaml is unreachable",
+		},
+		"request_timeout": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "30s",
+			ValidateFunc: ValidateDuration(),
+		},
+		"max_retries": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      DefaultRetryPolicy.MaxRetries,
+			Description:  "How many times to retry a request which failed with a transient error",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"retry_min_wait": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      DefaultRetryPolicy.MinWait.String(),
+			Description:  "How long to wait before the first retry. Doubles with each attempt",
+			ValidateFunc: ValidateDuration(),
+		},
+		"retry_max_wait": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      DefaultRetryPolicy.MaxWait.String(),
+			Description:  "The longest to wait between retries",
+			ValidateFunc: ValidateDuration(),
+		},
+		"max_concurrent_requests": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			Description:  "The most requests to have in flight at once. Zero for no limit",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"requests_per_second": {
+			Type:         schema.TypeFloat,
+			Optional:     true,
+			Default:      0,
+			Description:  "The most requests to start each second. Zero for no limit",
+			ValidateFunc: validation.FloatAtLeast(0),
+		},
+		"read_cache": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Fetch every object of a kind the first time one is read, to speed up refreshing large projects",
+		},
+		"ca_cert_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to a PEM encoded CA certificate to trust, in addition to the system roots",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_CA_CERT_FILE", nil),
+		},
+		"ca_cert_pem": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "A PEM encoded CA certificate to trust, in addition to the system roots",
+		},
+		"client_cert_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to a PEM encoded client certificate for mutual TLS",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_CLIENT_CERT_FILE", nil),
+		},
+		"client_cert_pem": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "A PEM encoded client certificate for mutual TLS",
+		},
+		"client_key_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path to the PEM encoded private key of the client certificate",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_CLIENT_KEY_FILE", nil),
+		},
+		"client_key_pem": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The PEM encoded private key of the client certificate",
+		},
+		"insecure_skip_verify": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Don't verify the server's TLS certificate. Only for testing",
+		},
+		"proxy_url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The proxy to send requests through. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables",
+			DefaultFunc:  schema.EnvDefaultFunc("ANAML_PROXY_URL", nil),
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+		"extra_headers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Additional HTTP headers to send with every request",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// ConfigureClient - A client connecting to Anaml as set in the common provider
+// settings. The connection isn't checked, as providers first finish setting up
+// the client for their own settings.
+func ConfigureClient(d *schema.ResourceData, branch *string) (*Client, diag.Diagnostics) {
+	var host *string
+
+	hVal, ok := d.GetOk("host")
+	if ok {
+		tempHost := hVal.(string)
+		host = &tempHost
+	}
+
+	timeout, err := time.ParseDuration(d.Get("request_timeout").(string))
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	auth, err := AuthenticatorFromConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	c, err := NewClient(host, auth, branch, timeout)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	retryMinWait, err := time.ParseDuration(d.Get("retry_min_wait").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	retryMaxWait, err := time.ParseDuration(d.Get("retry_max_wait").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	c.Retry = RequestRetryPolicy{
+		MaxRetries: d.Get("max_retries").(int),
+		MinWait:    retryMinWait,
+		MaxWait:    retryMaxWait,
+	}
+
+	err = c.ConfigureTransport(TransportConfigFromConfig(d))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	c.ConfigureLimits(d.Get("max_concurrent_requests").(int), d.Get("requests_per_second").(float64))
+
+	for _, label := range d.Get("default_labels").(*schema.Set).List() {
+		c.DefaultLabels = append(c.DefaultLabels, label.(string))
+	}
+	c.DefaultAttributes = make(map[string]string)
+	for key, value := range d.Get("default_attributes").(map[string]interface{}) {
+		c.DefaultAttributes[key] = value.(string)
+	}
+
+	if d.Get("read_cache").(bool) {
+		c.EnableReadCache()
+	}
+
+	return c, nil
+}
+
+// TransportConfigFromConfig - The TLS, proxy and header settings given in
+// a provider's configuration.
+func TransportConfigFromConfig(d *schema.ResourceData) TransportConfig {
+	headers := make(map[string]string)
+	for name, value := range d.Get("extra_headers").(map[string]interface{}) {
+		headers[name] = value.(string)
+	}
+
+	return TransportConfig{
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		ClientCertFile:     d.Get("client_cert_file").(string),
+		ClientCertPEM:      d.Get("client_cert_pem").(string),
+		ClientKeyFile:      d.Get("client_key_file").(string),
+		ClientKeyPEM:       d.Get("client_key_pem").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		ProxyURL:           d.Get("proxy_url").(string),
+		ExtraHeaders:       headers,
+	}
+}
+
+// AuthenticatorFromConfig - The credentials given in a provider's
+// configuration. Credentials are tried from most to least specific, so that a
+// token in the environment takes precedence over a username and password.
+func AuthenticatorFromConfig(d *schema.ResourceData) (Authenticator, error) {
+	if token := d.Get("token").(string); token != "" {
+		return &TokenAuth{Token: token}, nil
+	}
+
+	if id := d.Get("access_token_id").(string); id != "" {
+		secret := d.Get("access_token_secret").(string)
+		if secret == "" {
+			return nil, errors.New("access_token_secret must be set with access_token_id")
+		}
+		return &BasicAuth{Username: id, Password: secret}, nil
+	}
+
+	username := d.Get("username").(string)
+	password := d.Get("password").(string)
+	if username == "" || password == "" {
+		return nil, errors.New("No credentials set. Configure a token, an access token, or a username and password")
+	}
+
+	if d.Get("login").(bool) {
+		return &LoginAuth{Credentials: AuthStruct{Username: username, Password: password}}, nil
+	}
+
+	return &BasicAuth{Username: username, Password: password}, nil
+}
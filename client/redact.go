@@ -0,0 +1,163 @@
+package anaml
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+// Every object sent to or received from Anaml. Fields of these (or of any
+// type nested inside them) tagged `sensitive:"true"` are redacted from
+// debug logs, so new secrets only need the tag to be kept out of CI logs.
+var loggedModels = []interface{}{
+	AccessToken{},
+	AttributeRestriction{},
+	AuthResponse{},
+	AuthStruct{},
+	Branch{},
+	BranchCreationRequest{},
+	BranchProtection{},
+	ChangeOtherPasswordRequest{},
+	Cluster{},
+	Destination{},
+	Entity{},
+	EntityMapping{},
+	EntityPopulation{},
+	EventStore{},
+	Feature{},
+	FeaturePreview{},
+	FeaturePreviewRequest{},
+	FeatureSet{},
+	FeatureStore{},
+	FeatureTemplate{},
+	LabelRestriction{},
+	Lineage{},
+	MergeRequest{},
+	MetricsJob{},
+	MetricsSet{},
+	ServerInfo{},
+	Source{},
+	Table{},
+	TableCaching{},
+	TableMonitoring{},
+	TablePreview{},
+	TablePreviewRequest{},
+	TableSchema{},
+	User{},
+	UserGroup{},
+	ViewMaterialisationJob{},
+	Webhook{},
+}
+
+// JSON keys of all fields tagged as sensitive.
+var sensitiveKeys = collectSensitiveKeys(loggedModels)
+
+// Headers which carry credentials. Any extra headers configured on the
+// client are redacted as well, as they are commonly used for proxy auth.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+func collectSensitiveKeys(models []interface{}) map[string]bool {
+	keys := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	for _, model := range models {
+		collectSensitiveKeysOf(reflect.TypeOf(model), keys, visited)
+	}
+	return keys
+}
+
+func collectSensitiveKeysOf(t reflect.Type, keys map[string]bool, visited map[reflect.Type]bool) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		collectSensitiveKeysOf(t.Elem(), keys, visited)
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	if visited[t] {
+		return
+	}
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("sensitive") == "true" {
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = field.Name
+			}
+			keys[name] = true
+		}
+		collectSensitiveKeysOf(field.Type, keys, visited)
+	}
+}
+
+// Replaces the values of sensitive keys anywhere in a JSON document. Bodies
+// which aren't JSON are returned unchanged, Anaml only sends secrets as JSON.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return string(body)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return string(body)
+	}
+	return string(redactedBody)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, inner := range v {
+			if sensitiveKeys[key] && inner != nil && inner != "" {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(inner)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, inner := range v {
+			v[i] = redactValue(inner)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (c *Client) redactHeaders(headers http.Header) http.Header {
+	result := make(http.Header, len(headers))
+	for name, values := range headers {
+		if sensitiveHeaders[name] || c.isExtraHeader(name) {
+			result[name] = []string{redacted}
+		} else {
+			result[name] = values
+		}
+	}
+	return result
+}
+
+func (c *Client) isExtraHeader(name string) bool {
+	for extra := range c.ExtraHeaders {
+		if http.CanonicalHeaderKey(extra) == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package anaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Sets every sensitive string in a value, including inside nested structs,
+// pointers and slices, to a distinct secret, and returns the secrets.
+func fillSensitive(v reflect.Value, secrets *[]string, depth int) {
+	if depth > 8 {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		fillSensitive(v.Elem(), secrets, depth+1)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		if v.Len() == 0 {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		}
+		fillSensitive(v.Index(0), secrets, depth+1)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			value := v.Field(i)
+			if field.Tag.Get("sensitive") == "true" {
+				secret := fmt.Sprintf("secret-%d-%s", len(*secrets), field.Name)
+				if value.Kind() == reflect.Ptr {
+					value.Set(reflect.New(value.Type().Elem()))
+					value = value.Elem()
+				}
+				value.SetString(secret)
+				*secrets = append(*secrets, secret)
+				continue
+			}
+			fillSensitive(value, secrets, depth+1)
+		}
+	}
+}
+
+func TestRedactBodyLoggedModels(t *testing.T) {
+	total := 0
+	for _, model := range loggedModels {
+		value := reflect.New(reflect.TypeOf(model))
+		var secrets []string
+		fillSensitive(value.Elem(), &secrets, 0)
+		total += len(secrets)
+
+		body, err := json.Marshal(value.Interface())
+		if err != nil {
+			t.Fatalf("%T: %v", model, err)
+		}
+		for _, secret := range secrets {
+			if !strings.Contains(string(body), secret) {
+				t.Fatalf("%T: expected %s in the marshalled body", model, secret)
+			}
+		}
+
+		logged := redactBody(body)
+		for _, secret := range secrets {
+			if strings.Contains(logged, secret) {
+				t.Errorf("%T: %s was logged in %s", model, secret, logged)
+			}
+		}
+	}
+
+	if total == 0 {
+		t.Fatal("expected the logged models to have sensitive fields")
+	}
+}
+
+func TestRedactBodyNotJSON(t *testing.T) {
+	if logged := redactBody([]byte("not json")); logged != "not json" {
+		t.Errorf("expected a body which isn't JSON to be unchanged, got %s", logged)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	c := Client{ExtraHeaders: map[string]string{"x-proxy-token": "proxy-secret"}}
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer token-secret")
+	headers.Set("Cookie", "session=cookie-secret")
+	headers.Set("X-Proxy-Token", "proxy-secret")
+	headers.Set("Content-Type", "application/json")
+
+	logged := fmt.Sprint(c.redactHeaders(headers))
+	for _, secret := range []string{"token-secret", "cookie-secret", "proxy-secret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("%s was logged in %s", secret, logged)
+		}
+	}
+	if !strings.Contains(logged, "application/json") {
+		t.Errorf("expected headers without secrets to be logged, got %s", logged)
+	}
+}
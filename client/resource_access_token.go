@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,12 +17,12 @@ AccessTokens are how to authenticate with Anaml programmatically.
 
 func ResourceAccessToken() *schema.Resource {
 	return &schema.Resource{
-		Description: webhooksDescription,
-		Create:      resourceAccessTokenCreate,
-		Read:        resourceAccessTokenRead,
-		Delete:      resourceAccessTokenDelete,
+		Description:   webhooksDescription,
+		CreateContext: resourceAccessTokenCreate,
+		ReadContext:   resourceAccessTokenRead,
+		DeleteContext: resourceAccessTokenDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -53,26 +55,26 @@ func ResourceAccessToken() *schema.Resource {
 	}
 }
 
-func resourceAccessTokenRead(d *schema.ResourceData, m interface{}) error {
+func resourceAccessTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	tokenId := d.Id()
 
 	ownerKey, _ := d.GetChange("owner")
 	owner, _ := strconv.Atoi(ownerKey.(string))
 
-	token, err := c.GetAccessToken(owner, tokenId)
+	token, err := c.GetAccessToken(ctx, owner, tokenId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if token == nil {
 		d.SetId("")
 		return nil
 	}
 
-	return err
+	return nil
 }
 
-func resourceAccessTokenCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAccessTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	request := AccessToken{
 		Description: d.Get("description").(string),
@@ -80,25 +82,25 @@ func resourceAccessTokenCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	owner, _ := strconv.Atoi(d.Get("owner").(string))
 
-	token, err := c.CreateAccessToken(owner, request)
+	token, err := c.CreateAccessToken(ctx, owner, request)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(token.ID)
 	d.Set("secret", token.Secret)
-	return err
+	return nil
 }
 
-func resourceAccessTokenDelete(d *schema.ResourceData, m interface{}) error {
+func resourceAccessTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	tokenID := d.Id()
 	ownerKey, _ := d.GetChange("owner")
 	owner, _ := strconv.Atoi(ownerKey.(string))
 
-	err := c.DeleteAccessToken(owner, tokenID)
+	err := c.DeleteAccessToken(ctx, owner, tokenID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,9 +1,11 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -25,13 +27,14 @@ Multiple different types of attributes are supported:
 
 func ResourceAttributeRestriction() *schema.Resource {
 	return &schema.Resource{
-		Description: attributeDescription,
-		Create:      resourceAttributeRestrictionCreate,
-		Read:        resourceAttributeRestrictionRead,
-		Update:      resourceAttributeRestrictionUpdate,
-		Delete:      resourceAttributeRestrictionDelete,
+		Description:   attributeDescription,
+		CreateContext: resourceAttributeRestrictionCreate,
+		ReadContext:   resourceAttributeRestrictionRead,
+		UpdateContext: resourceAttributeRestrictionUpdate,
+		DeleteContext: resourceAttributeRestrictionDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -101,6 +104,7 @@ func ResourceAttributeRestriction() *schema.Resource {
 					}, false),
 				},
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
@@ -138,13 +142,13 @@ func enumChoiceSchema() *schema.Resource {
 	}
 }
 
-func resourceAttributeRestrictionRead(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	attributeID := d.Id()
 
-	attribute, err := c.GetAttributeRestriction(attributeID)
+	attribute, err := c.GetAttributeRestriction(ctx, attributeID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if attribute == nil {
 		d.SetId("")
@@ -152,92 +156,103 @@ func resourceAttributeRestrictionRead(d *schema.ResourceData, m interface{}) err
 	}
 
 	if err := d.Set("key", attribute.Key); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", attribute.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", attribute.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("mandatory", attribute.Mandatory); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("default_value", attribute.DefaultValue); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if attribute.Type == "enumattribute" {
 		e, err := parseEnumAttribute(attribute)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("enum", e); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("enum", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if err := d.Set("freetext", buildEmpty(attribute.Type == "freetextattribute")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("boolean", buildEmpty(attribute.Type == "booleanattribute")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("integer", buildEmpty(attribute.Type == "integerattribute")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("user", buildEmpty(attribute.Type == "userattribute")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("user_group", buildEmpty(attribute.Type == "usergroupattribute")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("applies_to", mapTargetsToFrontend(attribute.AppliesTo)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceAttributeRestrictionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	attribute, err := composeAttribute(d)
-	if attribute == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if attribute == nil {
+		return nil
 	}
 
-	a, err := c.CreateAttributeRestriction(*attribute)
+	a, err := c.CreateAttributeRestriction(ctx, *attribute)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(a.ID))
-	return err
+	return setVersion(ctx, d, c, "allowed-attribute")
 }
 
-func resourceAttributeRestrictionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	attributeID := d.Id()
 	attribute, err := composeAttribute(d)
-	if attribute == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if attribute == nil {
+		return nil
 	}
 
-	err = c.UpdateAttributeRestriction(attributeID, *attribute)
+	// Attribute restrictions are only updated conditionally if Anaml reported
+	// a version for them.
+	err = c.UpdateAttributeRestriction(ifMatchContext(ctx, d), attributeID, *attribute)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "allowed-attribute")
 }
 
-func resourceAttributeRestrictionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceAttributeRestrictionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	attributeID := d.Id()
 
-	err := c.DeleteAttributeRestriction(attributeID)
+	err := c.DeleteAttributeRestriction(ctx, attributeID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
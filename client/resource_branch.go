@@ -0,0 +1,137 @@
+package anaml
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const branchDescription = `# Branches
+
+A Branch is a line of development in the Anaml catalog, in the same way as a git branch.
+
+Branches are created from another branch or from a commit, and each change made to
+objects on a branch adds a new commit to it. Branches can be merged into one another
+through Change Requests.
+`
+
+func ResourceBranch() *schema.Resource {
+	return &schema.Resource{
+		Description:   branchDescription,
+		CreateContext: resourceBranchCreate,
+		ReadContext:   resourceBranchRead,
+		DeleteContext: resourceBranchDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"from_branch": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "The branch to create this branch from",
+				ExactlyOneOf:     []string{"from_branch", "from_commit"},
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressImportedSource,
+			},
+			"from_commit": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "The commit to create this branch from",
+				ValidateFunc:     validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: suppressImportedSource,
+			},
+			"head": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the latest commit on the branch",
+			},
+		},
+	}
+}
+
+// Anaml doesn't record where a branch was created from, so an imported
+// branch has no source in state. That shouldn't replace the branch.
+func suppressImportedSource(k, old, new string, d *schema.ResourceData) bool {
+	return old == "" && d.Id() != ""
+}
+
+func resourceBranchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	branchName := d.Id()
+
+	branch, err := c.GetBranch(ctx, branchName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if branch == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("name", branch.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("head", branch.Head.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func buildBranch(d *schema.ResourceData) BranchCreationRequest {
+	creationRequest := BranchCreationRequest{
+		Name: d.Get("name").(string),
+	}
+
+	if commit := d.Get("from_commit").(string); commit != "" {
+		creationRequest.From = VersionTarget{
+			Type:   "commit",
+			Commit: &commit,
+		}
+	} else {
+		branch := d.Get("from_branch").(string)
+		creationRequest.From = VersionTarget{
+			Type:   "branch",
+			Branch: &branch,
+		}
+	}
+
+	return creationRequest
+}
+
+func resourceBranchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	creationRequest := buildBranch(d)
+	branch, err := c.CreateBranch(ctx, creationRequest)
+	if err != nil {
+		return apiErrorDiagnostics(err, nil)
+	}
+	if branch == nil {
+		return diag.Errorf("Branch %s was not found after it was created", creationRequest.Name)
+	}
+
+	d.SetId(branch.Name)
+	return resourceBranchRead(ctx, d, m)
+}
+
+func resourceBranchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	branchName := d.Id()
+
+	err := c.DeleteBranch(ctx, branchName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -20,13 +22,14 @@ With Branch Protections you can:
 
 func ResourceBranchProtection() *schema.Resource {
 	return &schema.Resource{
-		Description: protectionDesc,
-		Create:      resourceBranchProtectionCreate,
-		Read:        resourceBranchProtectionRead,
-		Update:      resourceBranchProtectionUpdate,
-		Delete:      resourceBranchProtectionDelete,
+		Description:   protectionDesc,
+		CreateContext: resourceBranchProtectionCreate,
+		ReadContext:   resourceBranchProtectionRead,
+		UpdateContext: resourceBranchProtectionUpdate,
+		DeleteContext: resourceBranchProtectionDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -57,6 +60,7 @@ func ResourceBranchProtection() *schema.Resource {
 				Description: "Whether matching branches can be deleted.",
 				Required:    true,
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
@@ -152,13 +156,13 @@ func userGroupIdSchema() *schema.Resource {
 	}
 }
 
-func resourceBranchProtectionRead(d *schema.ResourceData, m interface{}) error {
+func resourceBranchProtectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	BranchProtectionID := d.Id()
 
-	BranchProtection, err := c.GetBranchProtection(BranchProtectionID)
+	BranchProtection, err := c.GetBranchProtection(ctx, BranchProtectionID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if BranchProtection == nil {
 		d.SetId("")
@@ -167,66 +171,71 @@ func resourceBranchProtectionRead(d *schema.ResourceData, m interface{}) error {
 
 	approvalRules, err := flattenApprovalRules(BranchProtection.MergeApprovalRules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("protection_pattern", BranchProtection.ProtectionPattern); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", BranchProtection.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("merge_approval_rules", approvalRules); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("push_whitelist", flattenPrincipalIds(BranchProtection.PushWhitelist)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("apply_to_admins", BranchProtection.ApplyToAdmins); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("allow_branch_deletion", BranchProtection.AllowBranchDeletion); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceBranchProtectionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceBranchProtectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	BranchProtection, err := composeBranchProtection(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateBranchProtection(*BranchProtection)
+	e, err := c.CreateBranchProtection(ctx, *BranchProtection)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "branch-protection")
 }
 
-func resourceBranchProtectionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceBranchProtectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	BranchProtectionID := d.Id()
 	BranchProtection, err := composeBranchProtection(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateBranchProtection(BranchProtectionID, *BranchProtection)
+	// Branch protection rules read without a version are replaced
+	// unconditionally.
+	err = c.UpdateBranchProtection(ifMatchContext(ctx, d), BranchProtectionID, *BranchProtection)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "branch-protection")
 }
 
-func resourceBranchProtectionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceBranchProtectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	BranchProtectionID := d.Id()
 
-	err := c.DeleteBranchProtection(BranchProtectionID)
+	err := c.DeleteBranchProtection(ctx, BranchProtectionID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
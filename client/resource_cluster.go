@@ -1,10 +1,12 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -38,13 +40,14 @@ Anaml Spark Server Clusters can be:
 
 func ResourceCluster() *schema.Resource {
 	return &schema.Resource{
-		Description: clusterDesc,
-		Create:      resourceClusterCreate,
-		Read:        resourceClusterRead,
-		Update:      resourceClusterUpdate,
-		Delete:      resourceClusterDelete,
+		Description:   clusterDesc,
+		CreateContext: resourceClusterCreate,
+		ReadContext:   resourceClusterRead,
+		UpdateContext: resourceClusterUpdate,
+		DeleteContext: resourceClusterDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, versionDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -107,6 +110,9 @@ func ResourceCluster() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
@@ -294,13 +300,13 @@ func gcpCredentialsProviderConfigSchema() *schema.Resource {
 	}
 }
 
-func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
+func resourceClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	clusterID := d.Id()
 
-	cluster, err := c.GetCluster(clusterID)
+	cluster, err := c.GetCluster(ctx, clusterID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if cluster == nil {
 		d.SetId("")
@@ -308,96 +314,118 @@ func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", cluster.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", cluster.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", cluster.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("is_preview_cluster", cluster.IsPreviewCluster); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	sparkConfig, err := parseSparkConfig(cluster.SparkConfig)
-	if sparkConfig == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if sparkConfig == nil {
+		return nil
 	}
 	if err := d.Set("spark_config", sparkConfig); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("property_set", flattenPropertySet(cluster.PropertySet)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if cluster.Type == "local" {
 		local, err := parseLocal(cluster)
-		if local == nil || err != nil {
-			return err
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if local == nil {
+			return nil
 		}
 		if err := d.Set("local", local); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if cluster.Type == "sparkserver" {
 		sparkServer, err := parseSparkServer(cluster)
-		if sparkServer == nil || err != nil {
-			return err
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if sparkServer == nil {
+			return nil
 		}
 		if err := d.Set("spark_server", sparkServer); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	if err := d.Set("labels", cluster.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, cluster.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(cluster.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, cluster.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceClusterCreate(d *schema.ResourceData, m interface{}) error {
+func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	cluster, err := composeCluster(d)
-	if cluster == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cluster == nil {
+		return nil
 	}
 
-	e, err := c.CreateCluster(*cluster)
+	e, err := c.CreateCluster(ctx, *cluster)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	resourceClusterRead(d, m)
-	return err
+	return resourceClusterRead(ctx, d, m)
 }
 
-func resourceClusterUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	clusterID := d.Id()
 	cluster, err := composeCluster(d)
-	if cluster == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if cluster == nil {
+		return nil
 	}
 
-	err = c.UpdateCluster(clusterID, *cluster)
+	err = c.UpdateCluster(ifMatchContext(ctx, d), clusterID, *cluster)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	resourceClusterRead(d, m)
-	return nil
+	return resourceClusterRead(ctx, d, m)
 }
 
-func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
+func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	clusterID := d.Id()
 
-	err := c.DeleteCluster(clusterID)
+	err := c.DeleteCluster(ctx, clusterID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,6 +1,13 @@
 package anaml
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -8,7 +15,14 @@ import (
 	"strconv"
 )
 
-func getAnamlId(d *schema.ResourceData, key string) (int, error) {
+// resourceGetter - Reads a resource's configuration, from its ResourceData
+// when applying or its ResourceDiff when planning.
+type resourceGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+func getAnamlId(d resourceGetter, key string) (int, error) {
 	if raw, ok := d.GetOk(key); ok {
 		if i, err := strconv.Atoi(raw.(string)); err == nil {
 			return i, nil
@@ -19,7 +33,7 @@ func getAnamlId(d *schema.ResourceData, key string) (int, error) {
 	return 0, fmt.Errorf("Required Identifier %s is missing", key)
 }
 
-func getAnamlIdPointer(d *schema.ResourceData, key string) *int {
+func getAnamlIdPointer(d resourceGetter, key string) *int {
 	if raw, ok := d.GetOk(key); ok {
 		if i, err := strconv.Atoi(raw.(string)); err == nil {
 			return &i
@@ -41,14 +55,417 @@ func getStringPointer(d *schema.ResourceData, key string) *string {
 	return nil
 }
 
+// attributeError reports an error against a single attribute of the
+// configuration, so Terraform can point at the offending line.
+func attributeError(key string, summary string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       summary,
+		AttributePath: cty.GetAttrPath(key),
+	}}
+}
+
+// Anaml's API fields which share a meaning with our top level attributes,
+// but not a name. Anything else is assumed to be the snake_case equivalent
+// of the API's camelCase field.
+var commonFieldAttributes = map[string]string{
+	"attributes": "attribute",
+}
+
+// apiErrorDiagnostics converts an error from the client into diagnostics.
+// Validation failures which Anaml attributes to a field of the object are
+// reported against the corresponding attribute of the configuration;
+// fieldAttributes overrides the attribute for fields whose names differ.
+func apiErrorDiagnostics(err error, fieldAttributes map[string]string) diag.Diagnostics {
+	if IsPreconditionFailed(err) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "The object has been changed in Anaml since Terraform last read it",
+			Detail:   "It was changed outside of Terraform, or by another run, after it was last refreshed. Run terraform plan again to review those changes before applying. " + err.Error(),
+		}}
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.FieldErrors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if apiErr.Message != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  apiErr.Message,
+		})
+	}
+
+	for _, fieldError := range apiErr.FieldErrors {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fieldError.Message,
+		}
+		if fieldError.Field != "" {
+			diagnostic.Detail = fmt.Sprintf("Anaml rejected the value of %s.", fieldError.Field)
+			diagnostic.AttributePath = cty.GetAttrPath(fieldAttribute(fieldError.Field, fieldAttributes))
+		}
+		diags = append(diags, diagnostic)
+	}
+
+	return diags
+}
+
+// Only the top level of a field path is mapped, nested fields (such as the
+// "sql" of "select.sql") are reported against the attribute containing them.
+func fieldAttribute(field string, fieldAttributes map[string]string) string {
+	if i := strings.IndexAny(field, ".["); i > 0 {
+		field = field[:i]
+	}
+	if attribute, ok := fieldAttributes[field]; ok {
+		return attribute
+	}
+	if attribute, ok := commonFieldAttributes[field]; ok {
+		return attribute
+	}
+
+	var snake strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				snake.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		snake.WriteRune(r)
+	}
+	return snake.String()
+}
+
+// notFoundError is returned by data sources when the object they read
+// doesn't exist. Unlike a resource, a data source which finds nothing is an
+// error, so configuration referring to it doesn't silently get an empty id.
+func notFoundError(kind string, key string, value string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("%s not found", kind),
+		Detail:        fmt.Sprintf("No %s with %s %q exists in Anaml.", kind, key, value),
+		AttributePath: cty.GetAttrPath(key),
+	}}
+}
+
+// branchSchema lets a single object be written to a branch other than the
+// one the provider is configured with. It's kept in state so that reads and
+// deletes follow the object.
+func branchSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Description:  "The branch to write this object to. Defaults to the provider's branch.",
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+}
+
+// branchContext directs requests for the resource to its branch, if set.
+func branchContext(ctx context.Context, d *schema.ResourceData) context.Context {
+	if branch, ok := d.GetOk("branch"); ok {
+		return WithBranch(ctx, branch.(string))
+	}
+	return ctx
+}
+
+// commitMessageSchema overrides the provider's commit message template for
+// writes to a single resource.
+func commitMessageSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Template for the message of commits made when writing this object. Defaults to the provider's commit_message.",
+		ValidateFunc: ValidateCommitMessage(),
+	}
+}
+
+func commitIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The commit made by the last write to this object.",
+	}
+}
+
+// objectVersionSchema records the version of the object last read, which
+// updates are made against so they can't overwrite changes made elsewhere.
+func objectVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The version of the object when it was last read or written.",
+	}
+}
+
+// writeResult - Where a write went and what it made, to be stored in state
+// once it succeeds.
+type writeResult struct {
+	kind   string
+	commit *commitRecorder
+}
+
+// writeContext directs a write to the resource's branch with its commit
+// message. Updates are only made if the object is still at the version in
+// state. The returned result holds the commit the write makes.
+func writeContext(ctx context.Context, d *schema.ResourceData, c *Client, resourceType string, operation string) (context.Context, *writeResult, diag.Diagnostics) {
+	ctx = branchContext(ctx, d)
+
+	if operation == "update" {
+		ctx = ifMatchContext(ctx, d)
+	}
+
+	tmpl := c.CommitMessage
+	if text := d.Get("commit_message").(string); text != "" {
+		var err error
+		tmpl, err = ParseCommitMessage(text)
+		if err != nil {
+			return nil, nil, attributeError("commit_message", err.Error())
+		}
+	}
+
+	if tmpl != nil {
+		data := CommitMessageData{
+			Operation:    operation,
+			ResourceType: resourceType,
+			ID:           d.Id(),
+			Workspace:    terraformWorkspace(),
+		}
+		if name, ok := d.Get("name").(string); ok {
+			data.Name = name
+		}
+		data.Object = resourceType + "." + data.Name
+		if data.Name == "" {
+			data.Object = resourceType + "." + data.ID
+		}
+		if branch := c.requestBranch(ctx); branch != nil {
+			data.Branch = *branch
+		}
+
+		message, err := renderCommitMessage(tmpl, data)
+		if err != nil {
+			return nil, nil, attributeError("commit_message", err.Error())
+		}
+		ctx = WithCommitMessage(ctx, message)
+	}
+
+	ctx, commit := recordCommit(ctx)
+	kind := strings.ReplaceAll(strings.TrimPrefix(resourceType, "anaml_"), "_", "-")
+	return ctx, &writeResult{kind: kind, commit: commit}, nil
+}
+
+// ifMatchContext makes an update only succeed if the object is still at the
+// version read into state. The plan marks the version as unknown, so it's
+// taken from the state the plan was made against.
+func ifMatchContext(ctx context.Context, d *schema.ResourceData) context.Context {
+	version, _ := d.GetChange("version")
+	return WithIfMatch(ctx, version.(string))
+}
+
+// Attributes which only change how the provider writes an object, rather
+// than the object itself. Changing only these doesn't write to Anaml.
+var writeOptionKeys = []string{"commit_message", "validate_sql"}
+
+// objectChanged is whether a plan changes the object in Anaml, rather than
+// only its write options.
+func objectChanged(d *schema.ResourceDiff) bool {
+	for _, key := range d.GetChangedKeysPrefix("") {
+		root := strings.Split(key, ".")[0]
+		option := false
+		for _, optionKey := range writeOptionKeys {
+			if root == optionKey {
+				option = true
+				break
+			}
+		}
+		if !option {
+			return true
+		}
+	}
+	return false
+}
+
+// skipWriteOptionUpdates stores changes to only an object's write options
+// in state without writing to Anaml, which would make an empty commit.
+func skipWriteOptionUpdates(update schema.UpdateContextFunc) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if !d.HasChangesExcept(writeOptionKeys...) {
+			return nil
+		}
+		return update(ctx, d, m)
+	}
+}
+
+// writeDiff marks the commit id and version as unknown in plans which
+// update the object, as the update will make new ones.
+func writeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !objectChanged(d) {
+		return nil
+	}
+	if err := d.SetNewComputed("commit_id"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("version")
+}
+
+// versionDiff marks the version as unknown in plans which update the object,
+// for resources which don't commit to a branch.
+func versionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	return d.SetNewComputed("version")
+}
+
+// setWriteResult stores the commit a write made, and the object's version
+// after it, so the next update is made against the version just written.
+func setWriteResult(ctx context.Context, d *schema.ResourceData, c *Client, write *writeResult) diag.Diagnostics {
+	if err := d.Set("commit_id", write.commit.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	return setVersion(ctx, d, c, write.kind)
+}
+
+// setVersion stores the object's version after a write by a resource which
+// doesn't read the object back.
+func setVersion(ctx context.Context, d *schema.ResourceData, c *Client, kind string) diag.Diagnostics {
+	version, err := c.GetVersion(ctx, kind, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// dataSourceSchema mirrors a resource's schema with every attribute computed,
+// so a data source can expose the whole object by reusing the resource's
+// Read. Attributes which only make sense when writing are left out.
+func dataSourceSchema(resource map[string]*schema.Schema, exclude ...string) map[string]*schema.Schema {
+	excluded := map[string]bool{
+		"commit_message": true,
+		"commit_id":      true,
+		"validate_sql":   true,
+	}
+	for _, key := range exclude {
+		excluded[key] = true
+	}
+
+	res := make(map[string]*schema.Schema, len(resource))
+	for key, s := range resource {
+		if !excluded[key] {
+			res[key] = computedSchema(s)
+		}
+	}
+	return res
+}
+
+func computedSchema(s *schema.Schema) *schema.Schema {
+	computed := &schema.Schema{
+		Type:        s.Type,
+		Computed:    true,
+		Description: s.Description,
+		Sensitive:   s.Sensitive,
+		Set:         s.Set,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Schema:
+		computed.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		computed.Elem = &schema.Resource{Schema: dataSourceSchema(elem.Schema)}
+	}
+	return computed
+}
+
+// Arguments letting a data source read the catalog as it is on another
+// branch, or as it was at a past commit, rather than at the head of the
+// provider's branch.
+func dataSourceVersionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"branch": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "Read the object from the head of this branch, rather than the provider's branch.",
+			ConflictsWith: []string{"commit"},
+			ValidateFunc:  validation.StringIsNotWhiteSpace,
+		},
+		"commit": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "Read the object as it was at this commit.",
+			ConflictsWith: []string{"branch"},
+			ValidateFunc:  validation.StringIsNotWhiteSpace,
+		},
+		"resolved_commit": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The commit the object was read at. Empty when the provider has no branch.",
+		},
+	}
+}
+
+// versionContext pins a data source's reads to the commit it asked for. A
+// branch is resolved to its head commit first, as is the branch the reads
+// would otherwise go to, so that every read made for the data source sees
+// the same version.
+func versionContext(ctx context.Context, d *schema.ResourceData, c *Client) (context.Context, error) {
+	commit := d.Get("commit").(string)
+
+	if branchName := d.Get("branch").(string); branchName != "" {
+		branch, err := c.GetBranch(ctx, branchName)
+		if err != nil {
+			return nil, err
+		}
+		if branch == nil {
+			return nil, fmt.Errorf("Branch %q does not exist", branchName)
+		}
+		commit = branch.Head.ID
+	} else if commit == "" {
+		head, err := c.headCommit(ctx)
+		if err != nil {
+			return nil, err
+		}
+		commit = head
+	}
+
+	if err := d.Set("resolved_commit", commit); err != nil {
+		return nil, err
+	}
+
+	if commit == "" {
+		return ctx, nil
+	}
+	return WithCommit(ctx, commit), nil
+}
+
+// importWithBranch accepts either a plain id, or "<branch>/<id>" to import
+// an object from a branch other than the provider's.
+func importWithBranch(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		if err := d.Set("branch", id[:i]); err != nil {
+			return nil, err
+		}
+		d.SetId(id[i+1:])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func labelSchema() *schema.Schema {
 	return &schema.Schema{
 		Type: schema.TypeString,
 	}
 }
 
-func expandLabels(d *schema.ResourceData) []string {
-	return expandStringList(d.Get("labels").(*schema.Set).List())
+// Labels and attributes are written from labels_all and attributes_all, so
+// they include the provider's defaults. See applyDefaults.
+func expandLabels(d resourceGetter) []string {
+	return expandStringList(d.Get("labels_all").(*schema.Set).List())
 }
 
 func attributeSchema() *schema.Resource {
@@ -163,8 +580,8 @@ func topicDestinationSchema() *schema.Resource {
 	}
 }
 
-func expandAttributes(d *schema.ResourceData) []Attribute {
-	drs := d.Get("attribute").(*schema.Set).List()
+func expandAttributes(d resourceGetter) []Attribute {
+	drs := d.Get("attributes_all").(*schema.Set).List()
 	return expandAttributesFromInterfaces(drs)
 }
 
@@ -0,0 +1,221 @@
+package anaml
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The provider's default_labels and default_attributes are added to every
+// object on write. labels_all and attributes_all hold everything the object
+// carries, and are what is written; labels and attribute only hold what was
+// configured on the resource, so the defaults never show up as a diff.
+
+func labelsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "Labels on the object, including the provider's default_labels",
+		Elem:        labelSchema(),
+	}
+}
+
+func attributesAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "Attributes on the object, including the provider's default_attributes",
+		Elem:        attributeSchema(),
+	}
+}
+
+// defaultsDiff plans labels_all and attributes_all from the resource's own
+// labels and attributes, and the provider's defaults.
+func defaultsDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c, _ := m.(*Client)
+
+	if !d.NewValueKnown("labels") {
+		if err := d.SetNewComputed("labels_all"); err != nil {
+			return err
+		}
+	} else {
+		labels := mergeDefaultLabels(c, expandStringList(d.Get("labels").(*schema.Set).List()))
+		if d.Id() == "" || !sameLabels(labels, expandStringList(d.Get("labels_all").(*schema.Set).List())) {
+			if err := d.SetNew("labels_all", labels); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !d.NewValueKnown("attribute") {
+		if err := d.SetNewComputed("attributes_all"); err != nil {
+			return err
+		}
+	} else {
+		attributes := mergeDefaultAttributes(c, expandAttributesFromInterfaces(d.Get("attribute").(*schema.Set).List()))
+		if d.Id() == "" || !sameAttributes(attributes, expandAttributesFromInterfaces(d.Get("attributes_all").(*schema.Set).List())) {
+			if err := d.SetNew("attributes_all", flattenAttributes(attributes)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyDefaults fills in labels_all and attributes_all before a write, as
+// they can't be planned when labels or attributes depend on other resources.
+func applyDefaults(d *schema.ResourceData, c *Client) error {
+	labels := mergeDefaultLabels(c, expandStringList(d.Get("labels").(*schema.Set).List()))
+	if err := d.Set("labels_all", labels); err != nil {
+		return err
+	}
+
+	attributes := mergeDefaultAttributes(c, expandAttributesFromInterfaces(d.Get("attribute").(*schema.Set).List()))
+	return d.Set("attributes_all", flattenAttributes(attributes))
+}
+
+// setLabels stores the labels read from Anaml. Default labels are left out
+// of labels unless they were also configured on the resource.
+func setLabels(d *schema.ResourceData, c *Client, labels []string) error {
+	if err := d.Set("labels_all", labels); err != nil {
+		return err
+	}
+
+	configured := make(map[string]bool)
+	for _, label := range expandStringList(d.Get("labels").(*schema.Set).List()) {
+		configured[label] = true
+	}
+	defaults := make(map[string]bool)
+	if c != nil {
+		for _, label := range c.DefaultLabels {
+			defaults[label] = true
+		}
+	}
+
+	own := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if !defaults[label] || configured[label] {
+			own = append(own, label)
+		}
+	}
+	return d.Set("labels", own)
+}
+
+// setAttributes stores the attributes read from Anaml. Attributes with the
+// default value are left out of attribute unless they were also configured
+// on the resource.
+func setAttributes(d *schema.ResourceData, c *Client, attributes []Attribute) error {
+	if err := d.Set("attributes_all", flattenAttributes(attributes)); err != nil {
+		return err
+	}
+
+	configured := make(map[string]bool)
+	for _, attribute := range expandAttributesFromInterfaces(d.Get("attribute").(*schema.Set).List()) {
+		configured[attribute.Key] = true
+	}
+
+	own := make([]Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		value, isDefault := "", false
+		if c != nil {
+			value, isDefault = c.DefaultAttributes[attribute.Key]
+		}
+		if !isDefault || value != attribute.Value || configured[attribute.Key] {
+			own = append(own, attribute)
+		}
+	}
+	return d.Set("attribute", flattenAttributes(own))
+}
+
+func mergeDefaultLabels(c *Client, labels []string) []string {
+	merged := append([]string{}, labels...)
+	if c == nil {
+		return merged
+	}
+
+	seen := make(map[string]bool)
+	for _, label := range labels {
+		seen[label] = true
+	}
+	for _, label := range c.DefaultLabels {
+		if !seen[label] {
+			merged = append(merged, label)
+			seen[label] = true
+		}
+	}
+	return merged
+}
+
+// Attributes configured on the resource take precedence over defaults with
+// the same key.
+func mergeDefaultAttributes(c *Client, attributes []Attribute) []Attribute {
+	merged := append([]Attribute{}, attributes...)
+	if c == nil {
+		return merged
+	}
+
+	seen := make(map[string]bool)
+	for _, attribute := range attributes {
+		seen[attribute.Key] = true
+	}
+
+	keys := make([]string, 0, len(c.DefaultAttributes))
+	for key := range c.DefaultAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !seen[key] {
+			merged = append(merged, Attribute{Key: key, Value: c.DefaultAttributes[key]})
+		}
+	}
+	return merged
+}
+
+func sameLabels(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]bool)
+	for _, label := range a {
+		set[label] = true
+	}
+	for _, label := range b {
+		if !set[label] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameAttributes(a []Attribute, b []Attribute) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[Attribute]bool)
+	for _, attribute := range a {
+		set[attribute] = true
+	}
+	for _, attribute := range b {
+		if !set[attribute] {
+			return false
+		}
+	}
+	return true
+}
+
+// customizeDiffs runs each of the functions in turn, stopping at the first
+// error.
+func customizeDiffs(funcs ...schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		for _, f := range funcs {
+			if err := f(ctx, d, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
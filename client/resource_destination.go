@@ -1,10 +1,12 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -25,13 +27,19 @@ Multiple different types of destinations are supported:
 
 func ResourceDestination() *schema.Resource {
 	return &schema.Resource{
-		Description: destinationDescription,
-		Create:      resourceDestinationCreate,
-		Read:        resourceDestinationRead,
-		Update:      resourceDestinationUpdate,
-		Delete:      resourceDestinationDelete,
+		Description:   destinationDescription,
+		CreateContext: resourceDestinationCreate,
+		ReadContext:   resourceDestinationRead,
+		UpdateContext: resourceDestinationUpdate,
+		DeleteContext: resourceDestinationDelete,
+		CustomizeDiff: customizeDiffs(
+			defaultsDiff,
+			versionDiff,
+			requireFeatureDiff(bigtableDestinations, "bigtable"),
+			requireFeatureDiff(snowflakeDestinations, "snowflake"),
+		),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -130,6 +138,9 @@ func ResourceDestination() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
@@ -187,13 +198,13 @@ func bigQueryPersistentStagingAreaSchema() *schema.Resource {
 	}
 }
 
-func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
+func resourceDestinationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	destinationID := d.Id()
 
-	destination, err := c.GetDestination(destinationID)
+	destination, err := c.GetDestination(ctx, destinationID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if destination == nil {
 		d.SetId("")
@@ -201,180 +212,195 @@ func resourceDestinationRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", destination.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", destination.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", destination.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if destination.Type == "s3" {
 		s3, err := parseS3Destination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("s3", s3); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "s3a" {
 		s3a, err := parseS3ADestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("s3a", s3a); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "gcs" {
 		gcs, err := parseS3Destination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("gcs", gcs); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "local" {
 		local, err := parseLocalDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("local", local); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "hdfs" {
 		hdfs, err := parseLocalDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("hdfs", hdfs); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "jdbc" {
 		jdbc, err := parseJDBCDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("jdbc", jdbc); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "hive" {
 		hive, err := parseHiveDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("hive", hive); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "bigquery" {
 		bigQuery, err := parseBigQueryDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("big_query", bigQuery); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "onlinefeaturestore" {
 		online, err := parseOnlineDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("online", online); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "bigtable" {
 		online, err := parseBigtableDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("bigtable", online); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "kafka" {
 		kafka, err := parseKafkaDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("kafka", kafka); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if destination.Type == "snowflake" {
 		snowflake, err := parseSnowflakeDestination(destination)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("snowflake", snowflake); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	if err := d.Set("labels", destination.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, destination.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(destination.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, destination.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceDestinationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceDestinationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	destination, err := composeDestination(d)
-	if destination == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if destination == nil {
+		return nil
 	}
 
-	e, err := c.CreateDestination(*destination)
+	e, err := c.CreateDestination(ctx, *destination)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "destination")
 }
 
-func resourceDestinationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceDestinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	destinationID := d.Id()
 	destination, err := composeDestination(d)
-	if destination == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if destination == nil {
+		return nil
 	}
 
-	err = c.UpdateDestination(destinationID, *destination)
+	err = c.UpdateDestination(ifMatchContext(ctx, d), destinationID, *destination)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "destination")
 }
 
-func resourceDestinationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceDestinationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	destinationID := d.Id()
 
-	err := c.DeleteDestination(destinationID)
+	err := c.DeleteDestination(ctx, destinationID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
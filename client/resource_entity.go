@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -27,13 +29,14 @@ Features will be generated for a specific Entity. This means the aggregation wil
 
 func ResourceEntity() *schema.Resource {
 	return &schema.Resource{
-		Description: entityDescription,
-		Create:      resourceEntityCreate,
-		Read:        resourceEntityRead,
-		Update:      resourceEntityUpdate,
-		Delete:      resourceEntityDelete,
+		Description:   entityDescription,
+		CreateContext: resourceEntityCreate,
+		ReadContext:   resourceEntityRead,
+		UpdateContext: skipWriteOptionUpdates(resourceEntityUpdate),
+		DeleteContext: resourceEntityDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, writeDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -83,17 +86,24 @@ func ResourceEntity() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
 
-func resourceEntityRead(d *schema.ResourceData, m interface{}) error {
+func resourceEntityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	entityID := d.Id()
 
-	entity, err := c.GetEntity(entityID)
+	entity, err := c.GetEntity(ctx, entityID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if entity == nil {
 		d.SetId("")
@@ -101,14 +111,17 @@ func resourceEntityRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", entity.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", entity.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", entity.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if entity.DefaultColumn != nil {
 		if err := d.Set("default_column", entity.DefaultColumn); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if entity.RequiredType != nil {
@@ -117,40 +130,40 @@ func resourceEntityRead(d *schema.ResourceData, m interface{}) error {
 
 			if ok {
 				if err := d.Set("required_type", requiredTypeString); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 			} else {
 				if err := d.Set("required_type", "Complex Type"); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 			}
 		} else {
 			if err := d.Set("required_type", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if err := d.Set("entities", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if entity.Entities != nil {
 		if err := d.Set("default_column", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("required_type", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("entities", identifierList(*entity.Entities)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
-	if err := d.Set("labels", entity.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, entity.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(entity.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, entity.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
 func buildEntity(d *schema.ResourceData) Entity {
@@ -177,37 +190,55 @@ func buildEntity(d *schema.ResourceData) Entity {
 	return entity
 }
 
-func resourceEntityCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_entity", "create")
+	if diags != nil {
+		return diags
+	}
 	entity := buildEntity(d)
-	e, err := c.CreateEntity(entity)
+	e, err := c.CreateEntity(ctx, entity)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceEntityUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_entity", "update")
+	if diags != nil {
+		return diags
+	}
 	entityID := d.Id()
 	entity := buildEntity(d)
-	err := c.UpdateEntity(entityID, entity)
+	err := c.UpdateEntity(ctx, entityID, entity)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceEntityDelete(d *schema.ResourceData, m interface{}) error {
+func resourceEntityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_entity", "delete")
+	if diags != nil {
+		return diags
+	}
 	entityID := d.Id()
 
-	err := c.DeleteEntity(entityID)
+	err := c.DeleteEntity(ctx, entityID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -27,13 +29,14 @@ you could define an Entity Mapping from Account to Customer:
 
 func ResourceEntityMapping() *schema.Resource {
 	return &schema.Resource{
-		Description: entityMappingDescription,
-		Create:      resourceEntityMappingCreate,
-		Read:        resourceEntityMappingRead,
-		Update:      resourceEntityMappingUpdate,
-		Delete:      resourceEntityMappingDelete,
+		Description:   entityMappingDescription,
+		CreateContext: resourceEntityMappingCreate,
+		ReadContext:   resourceEntityMappingRead,
+		UpdateContext: skipWriteOptionUpdates(resourceEntityMappingUpdate),
+		DeleteContext: resourceEntityMappingDelete,
+		CustomizeDiff: writeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -68,17 +71,22 @@ func ResourceEntityMapping() *schema.Resource {
 				ConflictsWith: []string{"one_to_many"},
 				Description:   "The mapping feature produce a single key (or null), which is related.",
 			},
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
 
-func resourceEntityMappingRead(d *schema.ResourceData, m interface{}) error {
+func resourceEntityMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	mappingID := d.Id()
 
-	mapping, err := c.GetEntityMapping(mappingID)
+	mapping, err := c.GetEntityMapping(ctx, mappingID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if mapping == nil {
 		d.SetId("")
@@ -86,28 +94,35 @@ func resourceEntityMappingRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("from", strconv.Itoa(mapping.From)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("to", strconv.Itoa(mapping.To)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("mapping", strconv.Itoa(mapping.Mapping)); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", mapping.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	falses, trues := flattenBooleanEmptys(mapping.OneToMany)
 
 	if err := d.Set("one_to_one", falses); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("one_to_many", trues); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceEntityMappingCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_entity_mapping", "create")
+	if diags != nil {
+		return diags
+	}
 	from, _ := getAnamlId(d, "from")
 	to, _ := getAnamlId(d, "to")
 	feat, _ := getAnamlId(d, "mapping")
@@ -119,17 +134,21 @@ func resourceEntityMappingCreate(d *schema.ResourceData, m interface{}) error {
 		OneToMany: booleanEmptys(d.Get("one_to_one").([]interface{}), d.Get("one_to_many").([]interface{})),
 	}
 
-	e, err := c.CreateEntityMapping(mapping)
+	e, err := c.CreateEntityMapping(ctx, mapping)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceEntityMappingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_entity_mapping", "update")
+	if diags != nil {
+		return diags
+	}
 	mappingID := d.Id()
 	from, _ := getAnamlId(d, "from")
 	to, _ := getAnamlId(d, "to")
@@ -142,21 +161,25 @@ func resourceEntityMappingUpdate(d *schema.ResourceData, m interface{}) error {
 		OneToMany: booleanEmptys(d.Get("one_to_one").([]interface{}), d.Get("one_to_many").([]interface{})),
 	}
 
-	err := c.UpdateEntityMapping(mappingID, mapping)
+	err := c.UpdateEntityMapping(ctx, mappingID, mapping)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceEntityMappingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceEntityMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_entity_mapping", "delete")
+	if diags != nil {
+		return diags
+	}
 	mappingID := d.Id()
 
-	err := c.DeleteEntityMapping(mappingID)
+	err := c.DeleteEntityMapping(ctx, mappingID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,138 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Plans and applies a change to the mapping of an entity mapping at version
+// v1, returning the If-Match its update was sent with.
+func applyEntityMappingUpdate(t *testing.T, status int) (string, error) {
+	var ifMatch string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/entity-mapping/9":
+			ifMatch = r.Header.Get("If-Match")
+			w.WriteHeader(status)
+		case r.Method == "GET" && r.URL.Path == "/entity-mapping/9":
+			w.Write([]byte(`{"id":9,"version":"v2","from":1,"to":2,"mapping":4}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resource := ResourceEntityMapping()
+	state := &terraform.InstanceState{
+		ID: "9",
+		Attributes: map[string]string{
+			"id":      "9",
+			"from":    "1",
+			"to":      "2",
+			"mapping": "3",
+			"version": "v1",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"from":    "1",
+		"to":      "2",
+		"mapping": "4",
+	})
+
+	diff, err := resource.Diff(context.Background(), state, config, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Attributes["version"].NewComputed {
+		t.Errorf("expected the plan to mark the version as unknown, got %+v", diff.Attributes["version"])
+	}
+
+	newState, diags := resource.Apply(context.Background(), state, diff, c)
+	if diags.HasError() {
+		return ifMatch, &diagnosticsError{diags[0].Summary}
+	}
+	if newState.Attributes["version"] != "v2" {
+		t.Errorf("expected the version after the update to be stored, got %q", newState.Attributes["version"])
+	}
+	return ifMatch, nil
+}
+
+type diagnosticsError struct {
+	summary string
+}
+
+func (e *diagnosticsError) Error() string {
+	return e.summary
+}
+
+func TestEntityMappingUpdateSendsIfMatch(t *testing.T) {
+	ifMatch, err := applyEntityMappingUpdate(t, http.StatusOK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ifMatch != `"v1"` {
+		t.Errorf("expected the update to be sent with If-Match \"v1\", got %q", ifMatch)
+	}
+}
+
+func TestEntityMappingUpdateConflict(t *testing.T) {
+	_, err := applyEntityMappingUpdate(t, http.StatusPreconditionFailed)
+	if err == nil || !strings.Contains(err.Error(), "changed in Anaml since Terraform last read it") {
+		t.Errorf("expected a conflict diagnostic, got %v", err)
+	}
+}
+
+func TestEntityMappingCommitMessageChangeDoesNotWrite(t *testing.T) {
+	writes := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/entity-mapping/9":
+			writes++
+		case r.Method == "GET" && r.URL.Path == "/entity-mapping/9":
+			w.Write([]byte(`{"id":9,"version":"v1","from":1,"to":2,"mapping":3}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resource := ResourceEntityMapping()
+	state := &terraform.InstanceState{
+		ID: "9",
+		Attributes: map[string]string{
+			"id":        "9",
+			"from":      "1",
+			"to":        "2",
+			"mapping":   "3",
+			"version":   "v1",
+			"commit_id": "c1",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"from":           "1",
+		"to":             "2",
+		"mapping":        "3",
+		"commit_message": "Map entities",
+	})
+
+	diff, err := resource.Diff(context.Background(), state, config, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Attributes["version"] != nil || diff.Attributes["commit_id"] != nil {
+		t.Errorf("expected the plan to keep the version and commit id, got %+v", diff.Attributes)
+	}
+
+	newState, diags := resource.Apply(context.Background(), state, diff, c)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if writes != 0 {
+		t.Errorf("expected no writes, got %d", writes)
+	}
+	if newState.Attributes["commit_message"] != "Map entities" || newState.Attributes["commit_id"] != "c1" {
+		t.Errorf("expected the new commit message and the old commit id in state, got %v", newState.Attributes)
+	}
+}
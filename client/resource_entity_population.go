@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -18,13 +20,14 @@ the second of which is named "date".
 
 func ResourceEntityPopulation() *schema.Resource {
 	return &schema.Resource{
-		Description: entityPopulationsDescription,
-		Create:      resourceEntityPopulationCreate,
-		Read:        resourceEntityPopulationRead,
-		Update:      resourceEntityPopulationUpdate,
-		Delete:      resourceEntityPopulationDelete,
+		Description:   entityPopulationsDescription,
+		CreateContext: resourceEntityPopulationCreate,
+		ReadContext:   resourceEntityPopulationRead,
+		UpdateContext: skipWriteOptionUpdates(resourceEntityPopulationUpdate),
+		DeleteContext: resourceEntityPopulationDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, writeDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -49,6 +52,8 @@ func ResourceEntityPopulation() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 			"entity": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -69,17 +74,22 @@ func ResourceEntityPopulation() *schema.Resource {
 				Required:    true,
 				Description: "The SQL expression which generates the entity population.",
 			},
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
 
-func resourceEntityPopulationRead(d *schema.ResourceData, m interface{}) error {
+func resourceEntityPopulationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	populationID := d.Id()
 
-	population, err := c.GetEntityPopulation(populationID)
+	population, err := c.GetEntityPopulation(ctx, populationID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if population == nil {
 		d.SetId("")
@@ -87,61 +97,82 @@ func resourceEntityPopulationRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", population.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", population.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", population.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", population.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, population.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(population.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, population.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("entity", strconv.Itoa(population.Entity)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("sources", identifierList(population.Sources)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("expression", population.Expression); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceEntityPopulationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityPopulationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_entity_population", "create")
+	if diags != nil {
+		return diags
+	}
 	population := buildPopulation(d)
-	e, err := c.CreateEntityPopulation(population)
+	e, err := c.CreateEntityPopulation(ctx, population)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceEntityPopulationUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEntityPopulationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_entity_population", "update")
+	if diags != nil {
+		return diags
+	}
 	populationID := d.Id()
 	population := buildPopulation(d)
-	err := c.UpdateEntityPopulation(populationID, population)
+	err := c.UpdateEntityPopulation(ctx, populationID, population)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceEntityPopulationDelete(d *schema.ResourceData, m interface{}) error {
+func resourceEntityPopulationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_entity_population", "delete")
+	if diags != nil {
+		return diags
+	}
 	populationID := d.Id()
 
-	err := c.DeleteEntityPopulation(populationID)
+	err := c.DeleteEntityPopulation(ctx, populationID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
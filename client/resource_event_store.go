@@ -1,9 +1,11 @@
 package anaml
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -12,13 +14,14 @@ const eventStoreDescription = `# Event Stores`
 
 func ResourceEventStore() *schema.Resource {
 	return &schema.Resource{
-		Description: eventStoreDescription,
-		Create:      resourceEventStoreCreate,
-		Read:        resourceEventStoreRead,
-		Update:      resourceEventStoreUpdate,
-		Delete:      resourceEventStoreDelete,
+		Description:   eventStoreDescription,
+		CreateContext: resourceEventStoreCreate,
+		ReadContext:   resourceEventStoreRead,
+		UpdateContext: resourceEventStoreUpdate,
+		DeleteContext: resourceEventStoreDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, versionDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -124,6 +127,9 @@ func ResourceEventStore() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
@@ -156,13 +162,13 @@ func ingestionSchema() *schema.Resource {
 	}
 }
 
-func resourceEventStoreRead(d *schema.ResourceData, m interface{}) error {
+func resourceEventStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	entityID := d.Id()
 
-	entity, err := c.GetEventStore(entityID)
+	entity, err := c.GetEventStore(ctx, entityID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if entity == nil {
@@ -174,7 +180,7 @@ func resourceEventStoreRead(d *schema.ResourceData, m interface{}) error {
 	for i, v := range entity.KafkaProperties {
 		sa, err := parseSensitiveAttribute(&v)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		sensitives[i] = sa
@@ -192,104 +198,113 @@ func resourceEventStoreRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", entity.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", entity.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", entity.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", entity.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, entity.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(entity.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, entity.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("bootstrap_servers", entity.BootstrapServers); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("schema_registry_url", entity.SchemaRegistryURL); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("property", sensitives); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("ingestion", ingestions); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("connect_base_uri", entity.ConnectBaseURI); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("batch_ingest_base_uri", entity.BatchIngestBaseURI); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("scatter_base_uri", entity.ScatterBaseURI); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("glacier_base_uri", entity.GlacierBaseURI); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster", strconv.Itoa(entity.Cluster)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster_property_sets", identifierList(entity.ClusterPropertySets)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("access_rules", flattenAccessRules(entity.AccessRules)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	daily, cron, dependency, err := parseSchedule(entity.Schedule)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("daily_schedule", daily); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cron_schedule", cron); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("dependency_schedule", dependency); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceEventStoreCreate(d *schema.ResourceData, m interface{}) error {
+func resourceEventStoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	eventStore, err := buildEventStore(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	e, err := c.CreateEventStore(*eventStore)
+	e, err := c.CreateEventStore(ctx, *eventStore)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "event-store")
 }
 
-func resourceEventStoreUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceEventStoreUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	eventStoreID := d.Id()
 	eventStore, err := buildEventStore(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateEventStore(eventStoreID, *eventStore)
+	err = c.UpdateEventStore(ifMatchContext(ctx, d), eventStoreID, *eventStore)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "event-store")
 }
 
-func resourceEventStoreDelete(d *schema.ResourceData, m interface{}) error {
+func resourceEventStoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	entityID := d.Id()
 
-	err := c.DeleteEventStore(entityID)
+	err := c.DeleteEventStore(ctx, entityID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,10 +1,11 @@
 package anaml
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -22,15 +23,24 @@ There are two types of Features:
 - Row Features
 `
 
+// Feature fields in the Anaml API which are named differently in our schema.
+var featureFieldAttributes = map[string]string{
+	"aggregate":         "aggregation",
+	"postAggregateExpr": "post_aggregation",
+	"entityId":          "entity",
+	"constraints":       "domain_modelling",
+}
+
 func ResourceFeature() *schema.Resource {
 	return &schema.Resource{
-		Description: featureDescription,
-		Create:      resourceFeatureCreate,
-		Read:        resourceFeatureRead,
-		Update:      resourceFeatureUpdate,
-		Delete:      resourceFeatureDelete,
+		Description:   featureDescription,
+		CreateContext: resourceFeatureCreate,
+		ReadContext:   resourceFeatureRead,
+		UpdateContext: skipWriteOptionUpdates(resourceFeatureUpdate),
+		DeleteContext: resourceFeatureDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, writeDiff, validateFeatureSQLDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -152,6 +162,8 @@ func ResourceFeature() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 
 			"domain_modelling": {
 				Type:             schema.TypeList,
@@ -161,6 +173,11 @@ func ResourceFeature() *schema.Resource {
 				Elem:             featureModellingSchema(),
 				DiffSuppressFunc: featureModellingDiffSuppressFunc(),
 			},
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
+			"validate_sql":   validateSQLSchema(),
 		},
 	}
 }
@@ -394,13 +411,14 @@ func featureModellingDiffSuppressFunc() schema.SchemaDiffSuppressFunc {
 	}
 }
 
-func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	featureID := d.Id()
 
-	feature, err := c.GetFeature(featureID)
+	feature, err := c.GetFeature(ctx, featureID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if feature == nil {
 		d.SetId("")
@@ -408,17 +426,20 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", feature.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", feature.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", feature.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("select", feature.Select.SQL); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if feature.Filter != nil {
 		if err := d.Set("filter", feature.Filter.SQL); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("filter", nil)
@@ -426,7 +447,7 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 
 	if feature.PostAggExpr != nil {
 		if err := d.Set("post_aggregation", feature.PostAggExpr.SQL); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("post_aggregation", nil)
@@ -434,7 +455,7 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 
 	if feature.TemplateID != nil {
 		if err := d.Set("template", strconv.Itoa(*feature.TemplateID)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("template", nil)
@@ -443,158 +464,159 @@ func resourceFeatureRead(d *schema.ResourceData, m interface{}) error {
 	if feature.Type == "event" {
 		if feature.Window.Type == "hourwindow" {
 			if err := d.Set("hours", feature.Window.Hours); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err = d.Set("hours", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if feature.Window.Type == "daywindow" {
 			if err := d.Set("days", feature.Window.Days); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err = d.Set("days", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if feature.Window.Type == "rowwindow" {
 			if err := d.Set("rows", feature.Window.Rows); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err := d.Set("rows", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if feature.Window.Type == "monthwindow" {
 			if err := d.Set("months", feature.Window.Months); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err := d.Set("months", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 
 		if err := d.Set("table", strconv.Itoa(feature.Table)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("aggregation", feature.Aggregate.Type); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if feature.EntityRestr != nil {
 			if err := d.Set("entity_restrictions", identifierList(*feature.EntityRestr)); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			d.Set("entity_restrictions", nil)
 		}
 	} else if feature.Type == "row" {
 		if err := d.Set("over", identifierList(feature.Over)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("entity", strconv.Itoa(feature.EntityID)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return errors.New("Unrecognised ADT type for feature")
+		return diag.Errorf("Unrecognised ADT type for feature")
 	}
 
-	if err := d.Set("labels", feature.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, feature.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(feature.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, feature.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	if len(feature.Constraints) > 0 {
 		bag := flattenColumnConstraints(feature.Constraints)
 		if err := d.Set("domain_modelling", []Bag{bag}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("domain_modelling", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceFeatureCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_feature", "create")
+	if diags != nil {
+		return diags
+	}
 	feature, err := buildFeature(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateFeature(*feature)
+	e, err := c.CreateFeature(ctx, *feature)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, featureFieldAttributes)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceFeatureUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_feature", "update")
+	if diags != nil {
+		return diags
+	}
 	featureID := d.Id()
 	table, err := buildFeature(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateFeature(featureID, *table)
+	err = c.UpdateFeature(ctx, featureID, *table)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, featureFieldAttributes)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceFeatureDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_feature", "delete")
+	if diags != nil {
+		return diags
+	}
 	featureID := d.Id()
 
-	err := c.DeleteFeature(featureID)
+	err := c.DeleteFeature(ctx, featureID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func buildFeature(d *schema.ResourceData) (*Feature, error) {
+func buildFeature(d resourceGetter) (*Feature, error) {
 	template := getAnamlIdPointer(d, "template")
 	feature := Feature{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Select: SQLExpression{
-			SQL: d.Get("select").(string),
-		},
-		Aggregate: &AggregateExpression{
-			Type: d.Get("aggregation").(string),
-		},
-		Labels:     expandLabels(d),
-		Attributes: expandAttributes(d),
-		TemplateID: template,
-	}
-
-	if filter, ok := d.GetOk("filter"); ok {
-		feature.Filter = &SQLExpression{
-			SQL: filter.(string),
-		}
-	}
-
-	if post, ok := d.GetOk("post_aggregation"); ok {
-		feature.PostAggExpr = &SQLExpression{
-			SQL: post.(string),
-		}
+		Labels:      expandLabels(d),
+		Attributes:  expandAttributes(d),
+		TemplateID:  template,
 	}
+	expandFeatureExpressions(d, &feature)
 
 	if table, ok := d.GetOk("table"); ok {
 		number, err := strconv.Atoi(table.(string))
@@ -603,26 +625,9 @@ func buildFeature(d *schema.ResourceData) (*Feature, error) {
 			return nil, err
 		}
 
-		window := EventWindow{}
-		if hours, ok := d.GetOk("hours"); ok {
-			window.Type = "hourwindow"
-			window.Hours = hours.(int)
-		} else if days, ok := d.GetOk("days"); ok {
-			window.Type = "daywindow"
-			window.Days = days.(int)
-		} else if months, ok := d.GetOk("months"); ok {
-			window.Type = "monthwindow"
-			window.Months = months.(int)
-		} else if rows, ok := d.GetOk("rows"); ok {
-			window.Type = "rowwindow"
-			window.Rows = rows.(int)
-		} else {
-			window.Type = "openwindow"
-		}
-
 		feature.Type = "event"
 		feature.Table = number
-		feature.Window = &window
+		feature.Window = expandEventWindow(d)
 		entity_restrictions := d.Get("entity_restrictions").([]interface{})
 		if len(entity_restrictions) > 0 {
 			listVal := expandIdentifierList(entity_restrictions)
@@ -651,3 +656,48 @@ func buildFeature(d *schema.ResourceData) (*Feature, error) {
 
 	return &feature, nil
 }
+
+// expandFeatureExpressions sets the SQL and aggregation of a feature, which
+// anaml_feature and inline anaml_feature_preview features share.
+func expandFeatureExpressions(d resourceGetter, feature *Feature) {
+	feature.Select = SQLExpression{
+		SQL: d.Get("select").(string),
+	}
+	feature.Aggregate = &AggregateExpression{
+		Type: d.Get("aggregation").(string),
+	}
+
+	if filter, ok := d.GetOk("filter"); ok {
+		feature.Filter = &SQLExpression{
+			SQL: filter.(string),
+		}
+	}
+
+	if post, ok := d.GetOk("post_aggregation"); ok {
+		feature.PostAggExpr = &SQLExpression{
+			SQL: post.(string),
+		}
+	}
+}
+
+// expandEventWindow is the window an event feature aggregates over. Without
+// hours, days, months or rows it's open, covering all of the table.
+func expandEventWindow(d resourceGetter) *EventWindow {
+	window := EventWindow{}
+	if hours, ok := d.GetOk("hours"); ok {
+		window.Type = "hourwindow"
+		window.Hours = hours.(int)
+	} else if days, ok := d.GetOk("days"); ok {
+		window.Type = "daywindow"
+		window.Days = days.(int)
+	} else if months, ok := d.GetOk("months"); ok {
+		window.Type = "monthwindow"
+		window.Months = months.(int)
+	} else if rows, ok := d.GetOk("rows"); ok {
+		window.Type = "rowwindow"
+		window.Rows = rows.(int)
+	} else {
+		window.Type = "openwindow"
+	}
+	return &window
+}
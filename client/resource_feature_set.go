@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -21,13 +23,14 @@ available to be chosen is restricted to Features for that Entity.
 
 func ResourceFeatureSet() *schema.Resource {
 	return &schema.Resource{
-		Description: featureSetDescription,
-		Create:      resourceFeatureSetCreate,
-		Read:        resourceFeatureSetRead,
-		Update:      resourceFeatureSetUpdate,
-		Delete:      resourceFeatureSetDelete,
+		Description:   featureSetDescription,
+		CreateContext: resourceFeatureSetCreate,
+		ReadContext:   resourceFeatureSetRead,
+		UpdateContext: skipWriteOptionUpdates(resourceFeatureSetUpdate),
+		DeleteContext: resourceFeatureSetDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, writeDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -67,17 +70,24 @@ func ResourceFeatureSet() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
 
-func resourceFeatureSetRead(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	FeatureSetID := d.Id()
 
-	FeatureSet, err := c.GetFeatureSet(FeatureSetID)
+	FeatureSet, err := c.GetFeatureSet(ctx, FeatureSetID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if FeatureSet == nil {
 		d.SetId("")
@@ -85,31 +95,41 @@ func resourceFeatureSetRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", FeatureSet.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", FeatureSet.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", FeatureSet.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("entity", strconv.Itoa(FeatureSet.EntityID)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("features", identifierList(FeatureSet.Features)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", FeatureSet.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, FeatureSet.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(FeatureSet.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, FeatureSet.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceFeatureSetCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_feature_set", "create")
+	if diags != nil {
+		return diags
+	}
 	entity, err := getAnamlId(d, "entity")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	FeatureSet := FeatureSet{
@@ -121,17 +141,24 @@ func resourceFeatureSetCreate(d *schema.ResourceData, m interface{}) error {
 		Attributes:  expandAttributes(d),
 	}
 
-	e, err := c.CreateFeatureSet(FeatureSet)
+	e, err := c.CreateFeatureSet(ctx, FeatureSet)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceFeatureSetUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_feature_set", "update")
+	if diags != nil {
+		return diags
+	}
 	entity, _ := strconv.Atoi(d.Get("entity").(string))
 	FeatureSetID := d.Id()
 
@@ -144,21 +171,25 @@ func resourceFeatureSetUpdate(d *schema.ResourceData, m interface{}) error {
 		Attributes:  expandAttributes(d),
 	}
 
-	err := c.UpdateFeatureSet(FeatureSetID, FeatureSet)
+	err := c.UpdateFeatureSet(ctx, FeatureSetID, FeatureSet)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceFeatureSetDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_feature_set", "delete")
+	if diags != nil {
+		return diags
+	}
 	FeatureSetID := d.Id()
 
-	err := c.DeleteFeatureSet(FeatureSetID)
+	err := c.DeleteFeatureSet(ctx, FeatureSetID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
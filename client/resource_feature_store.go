@@ -1,9 +1,10 @@
 package anaml
 
 import (
-	"errors"
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,13 +23,14 @@ An entity population can be used to further refine the entities and dates for fe
 
 func ResourceFeatureStore() *schema.Resource {
 	return &schema.Resource{
-		Description: featureStoreDescription,
-		Create:      resourceFeatureStoreCreate,
-		Read:        resourceFeatureStoreRead,
-		Update:      resourceFeatureStoreUpdate,
-		Delete:      resourceFeatureStoreDelete,
+		Description:   featureStoreDescription,
+		CreateContext: resourceFeatureStoreCreate,
+		ReadContext:   resourceFeatureStoreRead,
+		UpdateContext: resourceFeatureStoreUpdate,
+		DeleteContext: resourceFeatureStoreDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, versionDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -144,6 +146,8 @@ func ResourceFeatureStore() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 			"commit_target": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -155,17 +159,18 @@ func ResourceFeatureStore() *schema.Resource {
 				Description:   "Branch to run feature set (and population) for.",
 				ConflictsWith: []string{"commit_target"},
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
 
-func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	FeatureStoreID := d.Id()
 
-	FeatureStore, err := c.GetFeatureStore(FeatureStoreID)
+	FeatureStore, err := c.GetFeatureStore(ctx, FeatureStoreID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if FeatureStore == nil {
 		d.SetId("")
@@ -173,154 +178,163 @@ func resourceFeatureStoreRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", FeatureStore.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", FeatureStore.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", FeatureStore.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if FeatureStore.Type == "batch" {
 		if FeatureStore.StartDate != nil {
 			if err := d.Set("start_date", *FeatureStore.StartDate); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if FeatureStore.RunDateOffset != nil {
 			if err := d.Set("run_date_offset", *FeatureStore.RunDateOffset); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if FeatureStore.EndDate != nil {
 			if err := d.Set("end_date", *FeatureStore.EndDate); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 	if FeatureStore.Type == "streaming" {
 		if FeatureStore.Table == nil {
-			return errors.New("Required field is missing for streaming feature store: table")
+			return attributeError("table", "Required field is missing for streaming feature store: table")
 		}
 		if err := d.Set("table", *FeatureStore.Table); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if FeatureStore.Principal != nil {
 		if err := d.Set("principal", strconv.Itoa(*FeatureStore.Principal)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	destinations, err := flattenDestinationReferences(FeatureStore.Destinations)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("feature_set", strconv.Itoa(FeatureStore.FeatureSet)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("enabled", FeatureStore.Enabled); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("include_metadata", FeatureStore.IncludeMetadata); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("destination", destinations); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster", strconv.Itoa(FeatureStore.Cluster)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster_property_sets", identifierList(FeatureStore.ClusterPropertySets)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("additional_spark_properties", FeatureStore.AdditionalSparkProperties); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", FeatureStore.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, FeatureStore.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(FeatureStore.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, FeatureStore.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	if FeatureStore.Population != nil {
 		if err := d.Set("entity_population", strconv.Itoa(*FeatureStore.Population)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	daily, cron, dependency, err := parseSchedule(FeatureStore.Schedule)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("daily_schedule", daily); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cron_schedule", cron); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("dependency_schedule", dependency); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if FeatureStore.VersionTarget != nil {
 		if FeatureStore.VersionTarget.Commit != nil {
 			if err := d.Set("commit_target", FeatureStore.VersionTarget.Commit); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if err := d.Set("branch_target", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else if FeatureStore.VersionTarget.Branch != nil {
 			if err := d.Set("branch_target", FeatureStore.VersionTarget.Branch); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if err := d.Set("commit_target", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	} else {
 		if err := d.Set("commit_target", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("branch_target", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return err
+	return nil
 }
 
-func resourceFeatureStoreCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureStoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	FeatureStore, err := composeFeatureStore(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateFeatureStore(*FeatureStore)
+	e, err := c.CreateFeatureStore(ctx, *FeatureStore)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "feature-store")
 }
 
-func resourceFeatureStoreUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureStoreUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	FeatureStoreID := d.Id()
 	FeatureStore, err := composeFeatureStore(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateFeatureStore(FeatureStoreID, *FeatureStore)
+	err = c.UpdateFeatureStore(ifMatchContext(ctx, d), FeatureStoreID, *FeatureStore)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "feature-store")
 }
 
 func composeFeatureStore(d *schema.ResourceData) (*FeatureStore, error) {
@@ -384,13 +398,13 @@ func composeFeatureStore(d *schema.ResourceData) (*FeatureStore, error) {
 	return &featureStore, nil
 }
 
-func resourceFeatureStoreDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureStoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	FeatureStoreID := d.Id()
 
-	err := c.DeleteFeatureStore(FeatureStoreID)
+	err := c.DeleteFeatureStore(ctx, FeatureStoreID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
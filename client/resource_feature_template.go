@@ -1,9 +1,10 @@
 package anaml
 
 import (
-	"errors"
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -23,13 +24,14 @@ then that field will not be changed when the Feature Template is updated.
 // ResourceFeatureTemplate ...
 func ResourceFeatureTemplate() *schema.Resource {
 	return &schema.Resource{
-		Description: featureTemplateDescription,
-		Create:      resourceFeatureTemplateCreate,
-		Read:        resourceFeatureTemplateRead,
-		Update:      resourceFeatureTemplateUpdate,
-		Delete:      resourceFeatureTemplateDelete,
+		Description:   featureTemplateDescription,
+		CreateContext: resourceFeatureTemplateCreate,
+		ReadContext:   resourceFeatureTemplateRead,
+		UpdateContext: skipWriteOptionUpdates(resourceFeatureTemplateUpdate),
+		DeleteContext: resourceFeatureTemplateDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, writeDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -141,17 +143,24 @@ func ResourceFeatureTemplate() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
 
-func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	featureID := d.Id()
 
-	feature, err := c.GetFeatureTemplate(featureID)
+	feature, err := c.GetFeatureTemplate(ctx, featureID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if feature == nil {
 		d.SetId("")
@@ -159,17 +168,20 @@ func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", feature.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", feature.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", feature.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("select", feature.Select.SQL); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if feature.Filter != nil {
 		if err := d.Set("filter", feature.Filter.SQL); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("filter", nil)
@@ -177,7 +189,7 @@ func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
 
 	if feature.PostAggExpr != nil {
 		if err := d.Set("post_aggregation", feature.PostAggExpr.SQL); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("post_aggregation", nil)
@@ -186,52 +198,52 @@ func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
 	if feature.Type == "event" {
 		if feature.Window.Type == "hourwindow" {
 			if err := d.Set("hours", feature.Window.Hours); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err = d.Set("hours", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if feature.Window.Type == "daywindow" {
 			if err := d.Set("days", feature.Window.Days); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err = d.Set("days", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if feature.Window.Type == "rowwindow" {
 			if err := d.Set("rows", feature.Window.Rows); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err := d.Set("rows", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		if feature.Window.Type == "monthwindow" {
 			if err := d.Set("months", feature.Window.Months); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err := d.Set("months", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 
 		if err := d.Set("table", strconv.Itoa(feature.Table)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err := d.Set("aggregation", feature.Aggregate.Type); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if feature.EntityRestr != nil {
 			if err := d.Set("entity_restrictions", identifierList(*feature.EntityRestr)); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			d.Set("entity_restrictions", nil)
@@ -239,64 +251,82 @@ func resourceFeatureTemplateRead(d *schema.ResourceData, m interface{}) error {
 
 	} else if feature.Type == "row" {
 		if err := d.Set("over", identifierList(feature.Over)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("entity", strconv.Itoa(feature.EntityID)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
-		return errors.New("Unrecognised ADT type for feature")
+		return diag.Errorf("Unrecognised ADT type for feature")
 	}
 
-	if err := d.Set("labels", feature.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, feature.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(feature.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, feature.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceFeatureTemplateCreate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_feature_template", "create")
+	if diags != nil {
+		return diags
+	}
 	template, err := buildFeatureTemplate(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateFeatureTemplate(*template)
+	e, err := c.CreateFeatureTemplate(ctx, *template)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, featureFieldAttributes)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceFeatureTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_feature_template", "update")
+	if diags != nil {
+		return diags
+	}
 	templateID := d.Id()
 	template, err := buildFeatureTemplate(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateFeatureTemplate(templateID, *template)
+	err = c.UpdateFeatureTemplate(ctx, templateID, *template)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, featureFieldAttributes)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceFeatureTemplateDelete(d *schema.ResourceData, m interface{}) error {
+func resourceFeatureTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_feature_template", "delete")
+	if diags != nil {
+		return diags
+	}
 	templateID := d.Id()
 
-	err := c.DeleteFeatureTemplate(templateID)
+	err := c.DeleteFeatureTemplate(ctx, templateID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
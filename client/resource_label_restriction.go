@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,13 +17,14 @@ Restrictions limit the labels that can be applied to a given object.
 
 func ResourceLabelRestriction() *schema.Resource {
 	return &schema.Resource{
-		Description: labelDescription,
-		Create:      resourceLabelRestrictionCreate,
-		Read:        resourceLabelRestrictionRead,
-		Update:      resourceLabelRestrictionUpdate,
-		Delete:      resourceLabelRestrictionDelete,
+		Description:   labelDescription,
+		CreateContext: resourceLabelRestrictionCreate,
+		ReadContext:   resourceLabelRestrictionRead,
+		UpdateContext: resourceLabelRestrictionUpdate,
+		DeleteContext: resourceLabelRestrictionDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,17 +41,18 @@ func ResourceLabelRestriction() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
 
-func resourceLabelRestrictionRead(d *schema.ResourceData, m interface{}) error {
+func resourceLabelRestrictionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	labelID := d.Id()
 
-	label, err := c.GetLabelRestriction(labelID)
+	label, err := c.GetLabelRestriction(ctx, labelID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if label == nil {
 		d.SetId("")
@@ -56,49 +60,54 @@ func resourceLabelRestrictionRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("text", label.Text); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", label.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("emoji", label.Emoji); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("colour", label.Colour); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceLabelRestrictionCreate(d *schema.ResourceData, m interface{}) error {
+func resourceLabelRestrictionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	label := composeLabel(d)
-	l, err := c.CreateLabelRestriction(*label)
+	l, err := c.CreateLabelRestriction(ctx, *label)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(l.ID))
-	return err
+	return setVersion(ctx, d, c, "allowed-label")
 }
 
-func resourceLabelRestrictionUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceLabelRestrictionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	labelID := d.Id()
 	label := composeLabel(d)
-	err := c.UpdateLabelRestriction(labelID, *label)
+	// Label restrictions only carry a version on servers which track one;
+	// otherwise the update is unconditional.
+	err := c.UpdateLabelRestriction(ifMatchContext(ctx, d), labelID, *label)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "allowed-label")
 }
 
-func resourceLabelRestrictionDelete(d *schema.ResourceData, m interface{}) error {
+func resourceLabelRestrictionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	labelID := d.Id()
 
-	err := c.DeleteLabelRestriction(labelID)
+	err := c.DeleteLabelRestriction(ctx, labelID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
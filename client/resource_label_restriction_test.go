@@ -0,0 +1,83 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLabelRestrictionCreateAndRead(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/allowed-label":
+			w.Write([]byte(`4`))
+		case r.Method == "GET" && r.URL.Path == "/allowed-label/4":
+			w.Write([]byte(`{"id":4,"text":"pii","emoji":"","colour":"red"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resource := ResourceLabelRestriction()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"text": "pii"})
+
+	if diags := resource.CreateContext(context.Background(), d, c); diags != nil {
+		t.Fatalf("unexpected diagnostics creating: %v", diags)
+	}
+	if d.Id() != "4" {
+		t.Errorf("expected id 4, got %q", d.Id())
+	}
+
+	if diags := resource.ReadContext(context.Background(), d, c); diags != nil {
+		t.Fatalf("unexpected diagnostics reading: %v", diags)
+	}
+	if d.Get("colour") != "red" {
+		t.Errorf("expected the colour to be read, got %v", d.Get("colour"))
+	}
+}
+
+func TestLabelRestrictionUpdateIfMatch(t *testing.T) {
+	cases := []struct {
+		name    string
+		version string
+		ifMatch string
+	}{
+		{"versioned", "v1", `"v1"`},
+		{"unversioned", "", ""},
+	}
+
+	for _, tc := range cases {
+		ifMatch := "unset"
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == "PUT" && r.URL.Path == "/allowed-label/4":
+				ifMatch = r.Header.Get("If-Match")
+			case r.Method == "GET" && r.URL.Path == "/allowed-label/4":
+				w.Write([]byte(`{"id":4,"text":"sensitive"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+
+		resource := ResourceLabelRestriction()
+		state := &terraform.InstanceState{
+			ID:         "4",
+			Attributes: map[string]string{"id": "4", "text": "pii", "version": tc.version},
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{"text": "sensitive"})
+
+		diff, err := resource.Diff(context.Background(), state, config, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := resource.Apply(context.Background(), state, diff, c); diags.HasError() {
+			t.Errorf("%s: unexpected diagnostics updating: %v", tc.name, diags)
+		}
+		if ifMatch != tc.ifMatch {
+			t.Errorf("%s: expected the update to be sent with If-Match %q, got %q", tc.name, tc.ifMatch, ifMatch)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package anaml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const mergeRequestDescription = `# Merge Requests
+
+A Merge Request (or Change Request) asks for the commits on one branch to be merged into another.
+
+When the target branch is protected, the merge request must satisfy the branch protection's
+merge approval rules before it can be merged.
+`
+
+const (
+	mergeRequestOpen   = "open"
+	mergeRequestMerged = "merged"
+	mergeRequestClosed = "closed"
+)
+
+// How often a merge request is checked while waiting for it to be merged.
+var mergeRequestPollInterval = 10 * time.Second
+
+func ResourceMergeRequest() *schema.Resource {
+	return &schema.Resource{
+		Description:   mergeRequestDescription,
+		CreateContext: resourceMergeRequestCreate,
+		ReadContext:   resourceMergeRequestRead,
+		UpdateContext: resourceMergeRequestUpdate,
+		DeleteContext: resourceMergeRequestDelete,
+		CustomizeDiff: waitForMergeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_branch": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The branch with the changes to merge",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"target_branch": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The branch to merge the changes into",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"title": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"wait_for_merge": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until the merge request has been approved and merged, up to the create or update timeout. Open merge requests are waited for again on every apply",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "One of open, merged or closed",
+			},
+			"approvals": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The ids of the users who have approved the merge request",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"merge_commit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The commit the merge request was merged as, once merged",
+			},
+		},
+	}
+}
+
+func resourceMergeRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	mergeRequestID := d.Id()
+
+	mergeRequest, err := c.GetMergeRequest(ctx, mergeRequestID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if mergeRequest == nil {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("source_branch", mergeRequest.SourceBranch); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("target_branch", mergeRequest.TargetBranch); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("title", mergeRequest.Title); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", mergeRequest.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("status", mergeRequest.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("approvals", flattenMergeRequestApprovals(mergeRequest.Approvals)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("merge_commit", mergeRequest.MergeCommit); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func buildMergeRequest(d *schema.ResourceData) MergeRequest {
+	return MergeRequest{
+		SourceBranch: d.Get("source_branch").(string),
+		TargetBranch: d.Get("target_branch").(string),
+		Title:        d.Get("title").(string),
+		Description:  d.Get("description").(string),
+	}
+}
+
+func resourceMergeRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	mergeRequest := buildMergeRequest(d)
+	e, err := c.CreateMergeRequest(ctx, mergeRequest)
+	if err != nil {
+		return apiErrorDiagnostics(err, nil)
+	}
+
+	d.SetId(strconv.Itoa(e.ID))
+
+	if d.Get("wait_for_merge").(bool) {
+		err := waitForMerge(ctx, c, d.Id(), d.Timeout(schema.TimeoutCreate))
+		return mergeWaitDiagnostics(ctx, d, m, err)
+	}
+
+	return resourceMergeRequestRead(ctx, d, m)
+}
+
+func resourceMergeRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	mergeRequestID := d.Id()
+
+	if d.HasChanges("title", "description") {
+		mergeRequest := buildMergeRequest(d)
+		err := c.UpdateMergeRequest(ctx, mergeRequestID, mergeRequest)
+		if err != nil {
+			return apiErrorDiagnostics(err, nil)
+		}
+	}
+
+	if d.Get("wait_for_merge").(bool) {
+		err := waitForMerge(ctx, c, mergeRequestID, d.Timeout(schema.TimeoutUpdate))
+		return mergeWaitDiagnostics(ctx, d, m, err)
+	}
+
+	return resourceMergeRequestRead(ctx, d, m)
+}
+
+// An open merge request which is being waited for is updated by every plan,
+// so a wait which stopped is picked up again by the next apply.
+func waitForMergeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.Get("wait_for_merge").(bool) || d.Get("status").(string) != mergeRequestOpen {
+		return nil
+	}
+	return d.SetNewComputed("status")
+}
+
+// A wait which stopped before the merge request was merged or closed leaves
+// it open with its approvals, so it's kept in state with a warning rather than
+// failing the resource. Failing a create would taint it, and the next apply
+// would close the merge request and open a new one.
+func mergeWaitDiagnostics(ctx context.Context, d *schema.ResourceData, m interface{}, err error) diag.Diagnostics {
+	var stopped *mergeWaitStoppedError
+	if err != nil && !errors.As(err, &stopped) {
+		return diag.FromErr(err)
+	}
+
+	if stopped == nil {
+		return resourceMergeRequestRead(ctx, d, m)
+	}
+
+	warning := diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  stopped.Error(),
+		Detail:   fmt.Sprintf("Merge request %s is still open. It will be waited for again by the next apply.", d.Id()),
+	}
+
+	// An interrupted apply can't read the merge request again, the next
+	// refresh will.
+	if ctx.Err() != nil {
+		return diag.Diagnostics{warning}
+	}
+	return append(resourceMergeRequestRead(ctx, d, m), warning)
+}
+
+// Merged and closed merge requests are kept by Anaml as history, so only
+// open ones are closed on destroy.
+func resourceMergeRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	mergeRequestID := d.Id()
+
+	mergeRequest, err := c.GetMergeRequest(ctx, mergeRequestID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if mergeRequest == nil || mergeRequest.Status != mergeRequestOpen {
+		return nil
+	}
+
+	err = c.CloseMergeRequest(ctx, mergeRequestID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// mergeWaitStoppedError - The wait for a merge request ended, either because
+// the timeout passed or because Terraform was interrupted, while it was still
+// open.
+type mergeWaitStoppedError struct {
+	Cause          string
+	MergeRequestID string
+	Status         string
+	Approvals      int
+}
+
+func (e *mergeWaitStoppedError) Error() string {
+	return fmt.Sprintf("%s waiting for merge request %s to be approved and merged, status: %s, approvals: %d", e.Cause, e.MergeRequestID, e.Status, e.Approvals)
+}
+
+// Polls the merge request until it is merged, fails if it is closed or
+// removed, and gives up once the timeout has passed.
+func waitForMerge(ctx context.Context, c *Client, mergeRequestID string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stopped := &mergeWaitStoppedError{
+		Cause:          fmt.Sprintf("Timed out after %s", timeout),
+		MergeRequestID: mergeRequestID,
+		Status:         mergeRequestOpen,
+	}
+	stop := func() error {
+		if ctx.Err() != nil {
+			stopped.Cause = fmt.Sprintf("Stopped (%v)", ctx.Err())
+		}
+		return stopped
+	}
+
+	for {
+		mergeRequest, err := c.GetMergeRequest(waitCtx, mergeRequestID)
+		if err != nil {
+			if waitCtx.Err() != nil {
+				return stop()
+			}
+			return err
+		}
+		if mergeRequest == nil {
+			return fmt.Errorf("Merge request %s was deleted while waiting for it to be merged", mergeRequestID)
+		}
+
+		switch mergeRequest.Status {
+		case mergeRequestMerged:
+			return nil
+		case mergeRequestClosed:
+			return fmt.Errorf("Merge request %s was closed without being merged", mergeRequestID)
+		}
+
+		stopped.Status = mergeRequest.Status
+		stopped.Approvals = len(mergeRequest.Approvals)
+
+		log.Printf("[INFO] Waiting for merge request %s to be merged, status: %s, approvals: %d", mergeRequestID, mergeRequest.Status, len(mergeRequest.Approvals))
+		if err := sleepContext(waitCtx, mergeRequestPollInterval); err != nil {
+			return stop()
+		}
+	}
+}
+
+func flattenMergeRequestApprovals(approvals []MergeRequestApproval) []int {
+	res := make([]int, 0, len(approvals))
+	for _, approval := range approvals {
+		res = append(res, approval.UserID)
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package anaml
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// A server with one merge request which stays open.
+func openMergeRequestServer(t *testing.T, onGet func()) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/merge-request":
+			w.Write([]byte(`5`))
+		case r.Method == "GET" && r.URL.Path == "/merge-request/5":
+			if onGet != nil {
+				onGet()
+			}
+			w.Write([]byte(`{"id":5,"sourceBranch":"feature","targetBranch":"official","title":"t","description":"","status":"open","approvals":[{"userId":1}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func withPollInterval(t *testing.T, interval time.Duration) {
+	previous := mergeRequestPollInterval
+	mergeRequestPollInterval = interval
+	t.Cleanup(func() { mergeRequestPollInterval = previous })
+}
+
+func TestWaitForMergeTimesOut(t *testing.T) {
+	withPollInterval(t, 10*time.Millisecond)
+	c := openMergeRequestServer(t, nil)
+
+	err := waitForMerge(context.Background(), c, "5", 50*time.Millisecond)
+
+	var stopped *mergeWaitStoppedError
+	if !errors.As(err, &stopped) {
+		t.Fatalf("expected the wait to stop, got %v", err)
+	}
+	if !strings.HasPrefix(stopped.Error(), "Timed out after 50ms") || stopped.Approvals != 1 {
+		t.Errorf("unexpected error: %v", stopped)
+	}
+}
+
+func TestWaitForMergeCancelled(t *testing.T) {
+	withPollInterval(t, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	c := openMergeRequestServer(t, cancel)
+
+	err := waitForMerge(ctx, c, "5", time.Hour)
+
+	var stopped *mergeWaitStoppedError
+	if !errors.As(err, &stopped) {
+		t.Fatalf("expected the wait to stop, got %v", err)
+	}
+	if !strings.HasPrefix(stopped.Error(), "Stopped (context canceled)") {
+		t.Errorf("expected the cancellation to be reported, got %v", stopped)
+	}
+}
+
+func TestMergeRequestCreateKeepsStoppedWait(t *testing.T) {
+	withPollInterval(t, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	c := openMergeRequestServer(t, cancel)
+
+	resource := ResourceMergeRequest()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"source_branch":  "feature",
+		"target_branch":  "official",
+		"title":          "t",
+		"wait_for_merge": true,
+	})
+
+	diags := resource.CreateContext(ctx, d, c)
+	if diags.HasError() {
+		t.Fatalf("a stopped wait should not fail the resource: %v", diags)
+	}
+	if d.Id() != "5" {
+		t.Errorf("expected the merge request to be kept in state, got id %q", d.Id())
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning, got %v", diags)
+	}
+}
+
+func TestOpenMergeRequestIsWaitedForAgain(t *testing.T) {
+	resource := ResourceMergeRequest()
+	attributes := map[string]string{
+		"id":             "5",
+		"source_branch":  "feature",
+		"target_branch":  "official",
+		"title":          "t",
+		"wait_for_merge": "true",
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source_branch":  "feature",
+		"target_branch":  "official",
+		"title":          "t",
+		"wait_for_merge": true,
+	})
+
+	for status, planned := range map[string]bool{
+		mergeRequestOpen:   true,
+		mergeRequestMerged: false,
+		mergeRequestClosed: false,
+	} {
+		attributes["status"] = status
+		state := &terraform.InstanceState{ID: "5", Attributes: attributes}
+
+		diff, err := resource.Diff(context.Background(), state, config, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated := diff != nil && diff.Attributes["status"] != nil; updated != planned {
+			t.Errorf("%s: expected an update to be planned to be %v, got %v", status, planned, diff)
+		}
+	}
+}
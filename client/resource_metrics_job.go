@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -15,13 +17,14 @@ output locations.
 
 func ResourceMetricsJob() *schema.Resource {
 	return &schema.Resource{
-		Description: metricsJobDescription,
-		Create:      resourceMetricsJobCreate,
-		Read:        resourceMetricsJobRead,
-		Update:      resourceMetricsJobUpdate,
-		Delete:      resourceMetricsJobDelete,
+		Description:   metricsJobDescription,
+		CreateContext: resourceMetricsJobCreate,
+		ReadContext:   resourceMetricsJobRead,
+		UpdateContext: resourceMetricsJobUpdate,
+		DeleteContext: resourceMetricsJobDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, versionDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -46,6 +49,8 @@ func ResourceMetricsJob() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 			"metrics_set": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -111,17 +116,18 @@ func ResourceMetricsJob() *schema.Resource {
 				Description:   "Branch to run metrics set on.",
 				ConflictsWith: []string{"commit_target"},
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
 
-func resourceMetricsJobRead(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	MetricsJobID := d.Id()
 
-	MetricsJob, err := c.GetMetricsJob(MetricsJobID)
+	MetricsJob, err := c.GetMetricsJob(ctx, MetricsJobID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if MetricsJob == nil {
 		d.SetId("")
@@ -129,115 +135,126 @@ func resourceMetricsJobRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", MetricsJob.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", MetricsJob.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", MetricsJob.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("principal", strconv.Itoa(MetricsJob.Principal)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	destinations, err := flattenDestinationReferences(MetricsJob.Destinations)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("metrics_set", strconv.Itoa(MetricsJob.MetricsSet)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("enabled", MetricsJob.Enabled); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("destination", destinations); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster", strconv.Itoa(MetricsJob.Cluster)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster_property_sets", identifierList(MetricsJob.ClusterPropertySets)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", MetricsJob.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, MetricsJob.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(MetricsJob.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, MetricsJob.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 
 	daily, cron, dependency, err := parseSchedule(MetricsJob.Schedule)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("daily_schedule", daily); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cron_schedule", cron); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("dependency_schedule", dependency); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if MetricsJob.VersionTarget != nil {
 		if MetricsJob.VersionTarget.Commit != nil {
 			if err := d.Set("commit_target", MetricsJob.VersionTarget.Commit); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if err := d.Set("branch_target", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else if MetricsJob.VersionTarget.Branch != nil {
 			if err := d.Set("branch_target", MetricsJob.VersionTarget.Branch); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if err := d.Set("commit_target", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	} else {
 		if err := d.Set("commit_target", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("branch_target", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return err
+	return nil
 }
 
-func resourceMetricsJobCreate(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	MetricsJob, err := composeMetricsJob(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateMetricsJob(*MetricsJob)
+	e, err := c.CreateMetricsJob(ctx, *MetricsJob)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "metrics-job")
 }
 
-func resourceMetricsJobUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	MetricsJobID := d.Id()
 	MetricsJob, err := composeMetricsJob(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateMetricsJob(MetricsJobID, *MetricsJob)
+	// Anaml only reports a version for metrics jobs on servers which version
+	// them; without one the update isn't guarded against concurrent changes.
+	err = c.UpdateMetricsJob(ifMatchContext(ctx, d), MetricsJobID, *MetricsJob)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "metrics-job")
 }
 
 func composeMetricsJob(d *schema.ResourceData) (*MetricsJob, error) {
@@ -281,13 +298,13 @@ func composeMetricsJob(d *schema.ResourceData) (*MetricsJob, error) {
 	return &job, nil
 }
 
-func resourceMetricsJobDelete(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	MetricsJobID := d.Id()
 
-	err := c.DeleteMetricsJob(MetricsJobID)
+	err := c.DeleteMetricsJob(ctx, MetricsJobID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,9 +1,11 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,13 +17,14 @@ A Metrics Set is collection of metrics and dimensions that are used to perform b
 
 func ResourceMetricsSet() *schema.Resource {
 	return &schema.Resource{
-		Description: metricsSetDescription,
-		Create:      resourceMetricsSetCreate,
-		Read:        resourceMetricsSetRead,
-		Update:      resourceMetricsSetUpdate,
-		Delete:      resourceMetricsSetDelete,
+		Description:   metricsSetDescription,
+		CreateContext: resourceMetricsSetCreate,
+		ReadContext:   resourceMetricsSetRead,
+		UpdateContext: skipWriteOptionUpdates(resourceMetricsSetUpdate),
+		DeleteContext: resourceMetricsSetDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, writeDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -46,6 +49,8 @@ func ResourceMetricsSet() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 			"features_source": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -75,6 +80,10 @@ func ResourceMetricsSet() *schema.Resource {
 				Required: true,
 				Elem:     metricSchema(),
 			},
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
 		},
 	}
 }
@@ -201,13 +210,14 @@ func metricSchema() *schema.Resource {
 	}
 }
 
-func resourceMetricsSetRead(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	MetricsSetID := d.Id()
 
-	MetricsSet, err := c.GetMetricsSet(MetricsSetID)
+	MetricsSet, err := c.GetMetricsSet(ctx, MetricsSetID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if MetricsSet == nil {
 		d.SetId("")
@@ -215,69 +225,90 @@ func resourceMetricsSetRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", MetricsSet.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", MetricsSet.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", MetricsSet.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", MetricsSet.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, MetricsSet.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(MetricsSet.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, MetricsSet.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := readSource(d, MetricsSet.Source); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := readDimensions(d, MetricsSet.Dimensions); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := readMetrics(d, MetricsSet.Metrics); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceMetricsSetCreate(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_metrics_set", "create")
+	if diags != nil {
+		return diags
+	}
 
 	MetricsSet, err := buildMetricsSet(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	e, err := c.CreateMetricsSet(*MetricsSet)
+	e, err := c.CreateMetricsSet(ctx, *MetricsSet)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceMetricsSetUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_metrics_set", "update")
+	if diags != nil {
+		return diags
+	}
 	MetricsSetID := d.Id()
 
 	MetricsSet, err := buildMetricsSet(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = c.UpdateMetricsSet(MetricsSetID, *MetricsSet)
+	err = c.UpdateMetricsSet(ctx, MetricsSetID, *MetricsSet)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceMetricsSetDelete(d *schema.ResourceData, m interface{}) error {
+func resourceMetricsSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_metrics_set", "delete")
+	if diags != nil {
+		return diags
+	}
 	MetricsSetID := d.Id()
 
-	err := c.DeleteMetricsSet(MetricsSetID)
+	err := c.DeleteMetricsSet(ctx, MetricsSetID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,10 +1,12 @@
 package anaml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -26,13 +28,14 @@ Multiple different types of sources are supported:
 
 func ResourceSource() *schema.Resource {
 	return &schema.Resource{
-		Description: sourceDescription,
-		Create:      resourceSourceCreate,
-		Read:        resourceSourceRead,
-		Update:      resourceSourceUpdate,
-		Delete:      resourceSourceDelete,
+		Description:   sourceDescription,
+		CreateContext: resourceSourceCreate,
+		ReadContext:   resourceSourceRead,
+		UpdateContext: resourceSourceUpdate,
+		DeleteContext: resourceSourceDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, versionDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -118,12 +121,15 @@ func ResourceSource() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 			"access_rule": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Description: "Access rules to attach to the object",
 				Elem:        accessRuleSchema(),
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
@@ -654,13 +660,13 @@ func maskMaskingRuleSchema() *schema.Resource {
 	}
 }
 
-func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
+func resourceSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	sourceID := d.Id()
 
-	source, err := c.GetSource(sourceID)
+	source, err := c.GetSource(ctx, sourceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if source == nil {
 		d.SetId("")
@@ -668,163 +674,178 @@ func resourceSourceRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", source.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", source.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", source.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if source.Type == "s3" {
 		s3, err := parseS3Source(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("s3", s3); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "s3a" {
 		s3a, err := parseS3ASource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("s3a", s3a); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "gcs" {
 		gcs, err := parseS3Source(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("gcs", gcs); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "local" {
 		local, err := parseLocalSource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("local", local); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "hdfs" {
 		hdfs, err := parseLocalSource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("hdfs", hdfs); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "jdbc" {
 		jdbc, err := parseJDBCSource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("jdbc", jdbc); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "hive" {
 		hive, err := parseHiveSource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("hive", hive); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "bigquery" {
 		bigQuery, err := parseBigQuerySource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("big_query", bigQuery); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "kafka" {
 		kafka, err := parseKafkaSource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("kafka", kafka); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if source.Type == "snowflake" {
 		snowflake, err := parseSnowflakeSource(source)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("snowflake", snowflake); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	if err := d.Set("labels", source.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, source.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(source.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, source.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("access_rule", flattenAccessRules(source.AccessRules)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceSourceCreate(d *schema.ResourceData, m interface{}) error {
+func resourceSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	source, err := composeSource(d)
-	if source == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if source == nil {
+		return nil
 	}
 
-	e, err := c.CreateSource(*source)
+	e, err := c.CreateSource(ctx, *source)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "source")
 }
 
-func resourceSourceUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	sourceID := d.Id()
 	source, err := composeSource(d)
-	if source == nil || err != nil {
-		return err
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if source == nil {
+		return nil
 	}
 
-	err = c.UpdateSource(sourceID, *source)
+	err = c.UpdateSource(ifMatchContext(ctx, d), sourceID, *source)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "source")
 }
 
-func resourceSourceDelete(d *schema.ResourceData, m interface{}) error {
+func resourceSourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	sourceID := d.Id()
 
-	err := c.DeleteSource(sourceID)
+	err := c.DeleteSource(ctx, sourceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
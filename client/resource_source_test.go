@@ -0,0 +1,62 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSourceUpdateSendsIfMatch(t *testing.T) {
+	version := 1
+	var ifMatch string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/source":
+			w.Write([]byte(`5`))
+		case r.Method == "PUT" && r.URL.Path == "/source/5":
+			ifMatch = r.Header.Get("If-Match")
+			version++
+		case r.Method == "GET" && r.URL.Path == "/source/5":
+			fmt.Fprintf(w, `{"id":5,"version":"v%d","name":"events","description":"","adt_type":"local"}`, version)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	resource := ResourceSource()
+	apply := func(state *terraform.InstanceState, description string) *terraform.InstanceState {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":        "events",
+			"description": description,
+			"local": []interface{}{map[string]interface{}{
+				"path":        "/data/events",
+				"file_format": "parquet",
+			}},
+		})
+		diff, err := resource.Diff(context.Background(), state, config, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newState, diags := resource.Apply(context.Background(), state, diff, c)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return newState
+	}
+
+	state := apply(nil, "")
+	if state.Attributes["version"] != "v1" {
+		t.Fatalf("expected the created version to be stored, got %q", state.Attributes["version"])
+	}
+
+	state = apply(state, "Raw events")
+	if ifMatch != `"v1"` {
+		t.Errorf("expected the update to be sent with If-Match \"v1\", got %q", ifMatch)
+	}
+	if state.Attributes["version"] != "v2" {
+		t.Errorf("expected the updated version to be stored, got %q", state.Attributes["version"])
+	}
+}
@@ -1,9 +1,11 @@
 package anaml
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -88,15 +90,28 @@ generation, as well as their timestamp columns.
 `
 
 // ResourceTable ...
+// Table fields in the Anaml API which are named differently in our schema.
+var tableFieldAttributes = map[string]string{
+	"eventDescription": "event",
+	"columns":          "domain_modelling",
+}
+
 func ResourceTable() *schema.Resource {
 	return &schema.Resource{
-		Description: tableDescription,
-		Create:      resourceTableCreate,
-		Read:        resourceTableRead,
-		Update:      resourceTableUpdate,
-		Delete:      resourceTableDelete,
+		Description:   tableDescription,
+		CreateContext: resourceTableCreate,
+		ReadContext:   resourceTableRead,
+		UpdateContext: skipWriteOptionUpdates(resourceTableUpdate),
+		DeleteContext: resourceTableDelete,
+		CustomizeDiff: customizeDiffs(
+			defaultsDiff,
+			writeDiff,
+			requireFeatureDiff(eventStoreTables, "event_store"),
+			requireFeatureDiff(tableDomainModelling, "domain_modelling"),
+			validateViewSQLDiff,
+		),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importWithBranch,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -192,6 +207,8 @@ func ResourceTable() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 
 			"domain_modelling": {
 				Type:             schema.TypeList,
@@ -202,6 +219,11 @@ func ResourceTable() *schema.Resource {
 				Elem:             domainModellingSchema(),
 				DiffSuppressFunc: domainModellingSuppressFunc(),
 			},
+			"branch":         branchSchema(),
+			"commit_message": commitMessageSchema(),
+			"commit_id":      commitIDSchema(),
+			"version":        objectVersionSchema(),
+			"validate_sql":   validateSQLSchema(),
 		},
 	}
 }
@@ -694,13 +716,14 @@ func viewTableSchema() *schema.Resource {
 	}
 }
 
-func resourceTableRead(d *schema.ResourceData, m interface{}) error {
+func resourceTableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = branchContext(ctx, d)
 	tableID := d.Id()
 
-	table, err := c.GetTable(tableID)
+	table, err := c.GetTable(ctx, tableID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if table == nil {
@@ -709,115 +732,136 @@ func resourceTableRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", table.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", table.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", table.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	flattenEntityDescription(d, table.EventInfo)
 
 	if table.Type == "root" {
 		if err := d.Set("source", flattenSourceReferences(table.Source)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("source", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if table.Type == "view" {
 		if err := d.Set("view", flattenViewReferences(table)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("view", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if table.Type == "join" {
 		if err := d.Set("join", flattenJoinTableSpecification(table)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("join", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if table.Type == "pivot" {
 		if err := d.Set("pivot", flattenPivotReferences(table)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("pivot", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if table.Type == "eventstore" {
 		if err := d.Set("event_store", flattenEventStoreReferences(table.Source)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err := d.Set("event_store", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if err := d.Set("domain_modelling", flattenColumnInfo(table.Columns)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	if err := d.Set("labels", table.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, table.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(table.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, table.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceTableCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_table", "create")
+	if diags != nil {
+		return diags
+	}
 	table, err := buildTable(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	e, err := c.CreateTable(*table)
+	e, err := c.CreateTable(ctx, *table)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, tableFieldAttributes)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceTableUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, write, diags := writeContext(ctx, d, c, "anaml_table", "update")
+	if diags != nil {
+		return diags
+	}
 	tableID := d.Id()
 	table, err := buildTable(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateTable(tableID, *table)
+	err = c.UpdateTable(ctx, tableID, *table)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, tableFieldAttributes)
 	}
 
-	return nil
+	return setWriteResult(ctx, d, c, write)
 }
 
-func resourceTableDelete(d *schema.ResourceData, m interface{}) error {
+func resourceTableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx, _, diags := writeContext(ctx, d, c, "anaml_table", "delete")
+	if diags != nil {
+		return diags
+	}
 	tableID := d.Id()
 
-	err := c.DeleteTable(tableID)
+	err := c.DeleteTable(ctx, tableID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -5,18 +5,20 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceTableCaching() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTableCachingCreate,
-		Read:   resourceTableCachingRead,
-		Update: resourceTableCachingUpdate,
-		Delete: resourceTableCachingDelete,
+		CreateContext: resourceTableCachingCreate,
+		ReadContext:   resourceTableCachingRead,
+		UpdateContext: resourceTableCachingUpdate,
+		DeleteContext: resourceTableCachingDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,6 +93,7 @@ func ResourceTableCaching() *schema.Resource {
 					ValidateFunc: validateAnamlIdentifier(),
 				},
 			},
+			"version": objectVersionSchema(),
 		},
 
 		SchemaVersion: 1,
@@ -209,13 +212,13 @@ func specSchema() *schema.Resource {
 	}
 }
 
-func resourceTableCachingRead(d *schema.ResourceData, m interface{}) error {
+func resourceTableCachingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableCachingID := d.Id()
 
-	TableCaching, err := c.GetTableCaching(TableCachingID)
+	TableCaching, err := c.GetTableCaching(ctx, TableCachingID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if TableCaching == nil {
 		d.SetId("")
@@ -223,40 +226,43 @@ func resourceTableCachingRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", TableCaching.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", TableCaching.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", TableCaching.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("include", nil); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("auto", nil); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if TableCaching.Principal != nil {
 		if err := d.Set("principal", strconv.Itoa(*TableCaching.Principal)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	loc, plan := flattenTableCachingPlan(TableCaching.Plan)
 	if err := d.Set(loc, plan); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster", strconv.Itoa(TableCaching.Cluster)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster_property_sets", identifierList(TableCaching.ClusterPropertySets)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("prefix_url", TableCaching.PrefixURI); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if TableCaching.Retainement != nil {
 		if err := d.Set("retainment", TableCaching.Retainement); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("retainment", nil)
@@ -264,51 +270,53 @@ func resourceTableCachingRead(d *schema.ResourceData, m interface{}) error {
 
 	daily, cron, dependency, err := parseSchedule(TableCaching.Schedule)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("daily_schedule", daily); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cron_schedule", cron); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("dependency_schedule", dependency); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceTableCachingCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTableCachingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableCaching, err := composeTableCaching(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateTableCaching(*TableCaching)
+	e, err := c.CreateTableCaching(ctx, *TableCaching)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "table-caching")
 }
 
-func resourceTableCachingUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTableCachingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableCachingID := d.Id()
 	TableCaching, err := composeTableCaching(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateTableCaching(TableCachingID, *TableCaching)
+	// Table caching jobs aren't versioned by every Anaml server. When the job
+	// was read without a version, the update is sent without If-Match.
+	err = c.UpdateTableCaching(ifMatchContext(ctx, d), TableCachingID, *TableCaching)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "table-caching")
 }
 
 func composeTableCaching(d *schema.ResourceData) (*TableCaching, error) {
@@ -340,13 +348,13 @@ func composeTableCaching(d *schema.ResourceData) (*TableCaching, error) {
 	}, nil
 }
 
-func resourceTableCachingDelete(d *schema.ResourceData, m interface{}) error {
+func resourceTableCachingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableCachingID := d.Id()
 
-	err := c.DeleteTableCaching(TableCachingID)
+	err := c.DeleteTableCaching(ctx, TableCachingID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
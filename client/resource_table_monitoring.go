@@ -5,17 +5,19 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func ResourceTableMonitoring() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTableMonitoringCreate,
-		Read:   resourceTableMonitoringRead,
-		Update: resourceTableMonitoringUpdate,
-		Delete: resourceTableMonitoringDelete,
+		CreateContext: resourceTableMonitoringCreate,
+		ReadContext:   resourceTableMonitoringRead,
+		UpdateContext: resourceTableMonitoringUpdate,
+		DeleteContext: resourceTableMonitoringDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -85,6 +87,7 @@ func ResourceTableMonitoring() *schema.Resource {
 					ValidateFunc: validateAnamlIdentifier(),
 				},
 			},
+			"version": objectVersionSchema(),
 		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
@@ -99,12 +102,12 @@ func ResourceTableMonitoring() *schema.Resource {
 
 func ResourceTableMonitoringV0() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTableMonitoringCreate,
-		Read:   resourceTableMonitoringRead,
-		Update: resourceTableMonitoringUpdate,
-		Delete: resourceTableMonitoringDelete,
+		CreateContext: resourceTableMonitoringCreate,
+		ReadContext:   resourceTableMonitoringRead,
+		UpdateContext: resourceTableMonitoringUpdate,
+		DeleteContext: resourceTableMonitoringDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -216,13 +219,13 @@ func resourceTableMonitoringUpgradeV0(ctx context.Context, rawState map[string]i
 	return rawState, nil
 }
 
-func resourceTableMonitoringRead(d *schema.ResourceData, m interface{}) error {
+func resourceTableMonitoringRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableMonitoringID := d.Id()
 
-	TableMonitoring, err := c.GetTableMonitoring(TableMonitoringID)
+	TableMonitoring, err := c.GetTableMonitoring(ctx, TableMonitoringID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if TableMonitoring == nil {
 		d.SetId("")
@@ -230,83 +233,88 @@ func resourceTableMonitoringRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", TableMonitoring.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", TableMonitoring.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", TableMonitoring.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("include", nil); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("auto", nil); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	loc, plan := flattenTableMonitoringPlan(TableMonitoring.Plan)
 	if err := d.Set(loc, plan); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("enabled", TableMonitoring.Enabled); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if TableMonitoring.Principal != nil {
 		if err := d.Set("principal", strconv.Itoa(*TableMonitoring.Principal)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if err := d.Set("cluster", strconv.Itoa(TableMonitoring.Cluster)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster_property_sets", identifierList(TableMonitoring.ClusterPropertySets)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	daily, cron, dependency, err := parseSchedule(TableMonitoring.Schedule)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("daily_schedule", daily); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cron_schedule", cron); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("dependency_schedule", dependency); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceTableMonitoringCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTableMonitoringCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableMonitoring, err := composeTableMonitoring(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateTableMonitoring(*TableMonitoring)
+	e, err := c.CreateTableMonitoring(ctx, *TableMonitoring)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "table-monitoring")
 }
 
-func resourceTableMonitoringUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTableMonitoringUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableMonitoringID := d.Id()
 	TableMonitoring, err := composeTableMonitoring(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateTableMonitoring(TableMonitoringID, *TableMonitoring)
+	// As with table caching, monitoring jobs read without a version are updated
+	// without If-Match.
+	err = c.UpdateTableMonitoring(ifMatchContext(ctx, d), TableMonitoringID, *TableMonitoring)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "table-monitoring")
 }
 
 func composeTableMonitoring(d *schema.ResourceData) (*TableMonitoring, error) {
@@ -336,13 +344,13 @@ func composeTableMonitoring(d *schema.ResourceData) (*TableMonitoring, error) {
 	}, nil
 }
 
-func resourceTableMonitoringDelete(d *schema.ResourceData, m interface{}) error {
+func resourceTableMonitoringDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	TableMonitoringID := d.Id()
 
-	err := c.DeleteTableMonitoring(TableMonitoringID)
+	err := c.DeleteTableMonitoring(ctx, TableMonitoringID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,20 +1,23 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -48,17 +51,18 @@ func ResourceUser() *schema.Resource {
 					ValidateFunc: validation.StringInSlice(validRoles(), false),
 				},
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
 
-func resourceUserRead(d *schema.ResourceData, m interface{}) error {
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	userID := d.Id()
 
-	user, err := c.GetUser(userID)
+	user, err := c.GetUser(ctx, userID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if user == nil {
 		d.SetId("")
@@ -66,25 +70,28 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", user.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", user.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("email", user.Email); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("given_name", user.GivenName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("surname", user.Surname); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("roles", mapRolesToFrontend(user.Roles)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return nil
 }
 
-func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	user := User{
 		Name:      d.Get("name").(string),
@@ -95,16 +102,16 @@ func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
 		Roles:     mapRolesToBackend(expandStringList(d.Get("roles").([]interface{}))),
 	}
 
-	e, err := c.CreateUser(user)
+	e, err := c.CreateUser(ctx, user)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "user")
 }
 
-func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	userID := d.Id()
 	user := User{
@@ -115,29 +122,31 @@ func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
 		Roles:     mapRolesToBackend(expandStringList(d.Get("roles").([]interface{}))),
 	}
 
-	err := c.UpdateUser(userID, user)
+	// Users are only guarded against concurrent changes if Anaml reports their
+	// version. The password update that follows is never guarded.
+	err := c.UpdateUser(ifMatchContext(ctx, d), userID, user)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	if d.HasChange("password") {
 		password := getNullableString(d, "password")
-		err = c.UpdateUserPassword(userID, password)
+		err = c.UpdateUserPassword(ctx, userID, password)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "user")
 }
 
-func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	userID := d.Id()
 
-	err := c.DeleteUser(userID)
+	err := c.DeleteUser(ctx, userID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
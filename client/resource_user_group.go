@@ -1,20 +1,23 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceUserGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserGroupCreate,
-		Read:   resourceUserGroupRead,
-		Update: resourceUserGroupUpdate,
-		Delete: resourceUserGroupDelete,
+		CreateContext: resourceUserGroupCreate,
+		ReadContext:   resourceUserGroupRead,
+		UpdateContext: resourceUserGroupUpdate,
+		DeleteContext: resourceUserGroupDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -50,6 +53,7 @@ func ResourceUserGroup() *schema.Resource {
 				Computed:    true,
 				Elem:        userGroupMemberSchema(),
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
@@ -66,13 +70,13 @@ func userGroupMemberSchema() *schema.Resource {
 	}
 }
 
-func resourceUserGroupRead(d *schema.ResourceData, m interface{}) error {
+func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	UserGroupID := d.Id()
 
-	UserGroup, err := c.GetUserGroup(UserGroupID)
+	UserGroup, err := c.GetUserGroup(ctx, UserGroupID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if UserGroup == nil {
 		d.SetId("")
@@ -82,32 +86,35 @@ func resourceUserGroupRead(d *schema.ResourceData, m interface{}) error {
 	AnamlGroupMembers, ExternalGroupMembers := flattenUserGroupMembers(UserGroup.Members)
 
 	if err := d.Set("name", UserGroup.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", UserGroup.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", UserGroup.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("roles", mapRolesToFrontend(UserGroup.Roles)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("members", AnamlGroupMembers); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("external_group_id", UserGroup.ExternalGroupID); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("external_members", ExternalGroupMembers); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
+func resourceUserGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
 	groupMembers, err := expandUserGroupMembers(d.Get("members").(*schema.Set).List())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	UserGroup := UserGroup{
 		Name:            d.Get("name").(string),
@@ -117,22 +124,22 @@ func resourceUserGroupCreate(d *schema.ResourceData, m interface{}) error {
 		ExternalGroupID: getNullableString(d, "external_group_id"),
 	}
 
-	ug, err := c.CreateUserGroup(UserGroup)
+	ug, err := c.CreateUserGroup(ctx, UserGroup)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(ug.ID))
-	return err
+	return setVersion(ctx, d, c, "user-group")
 }
 
-func resourceUserGroupUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	UserGroupID := d.Id()
 
 	groupMembers, err := expandUserGroupMembers(d.Get("members").(*schema.Set).List())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	UserGroup := UserGroup{
 		Name:            d.Get("name").(string),
@@ -142,21 +149,23 @@ func resourceUserGroupUpdate(d *schema.ResourceData, m interface{}) error {
 		ExternalGroupID: getNullableString(d, "external_group_id"),
 	}
 
-	err = c.UpdateUserGroup(UserGroupID, UserGroup)
+	// A user group read without a version is updated without If-Match, so
+	// membership changes made in Anaml meanwhile are overwritten.
+	err = c.UpdateUserGroup(ifMatchContext(ctx, d), UserGroupID, UserGroup)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "user-group")
 }
 
-func resourceUserGroupDelete(d *schema.ResourceData, m interface{}) error {
+func resourceUserGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	UserGroupID := d.Id()
 
-	err := c.DeleteUserGroup(UserGroupID)
+	err := c.DeleteUserGroup(ctx, UserGroupID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
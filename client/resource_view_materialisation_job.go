@@ -1,9 +1,11 @@
 package anaml
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -16,13 +18,14 @@ View materialisation offer a simple way of scheduling arbitrary SQL table expres
 
 func ResourceViewMaterialisationJob() *schema.Resource {
 	return &schema.Resource{
-		Description: viewMaterialisationDescription,
-		Create:      resourceViewMaterialisationJobCreate,
-		Read:        resourceViewMaterialisationJobRead,
-		Update:      resourceViewMaterialisationJobUpdate,
-		Delete:      resourceViewMaterialisationJobDelete,
+		Description:   viewMaterialisationDescription,
+		CreateContext: resourceViewMaterialisationJobCreate,
+		ReadContext:   resourceViewMaterialisationJobRead,
+		UpdateContext: resourceViewMaterialisationJobUpdate,
+		DeleteContext: resourceViewMaterialisationJobDelete,
+		CustomizeDiff: customizeDiffs(defaultsDiff, versionDiff),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -111,6 +114,8 @@ func ResourceViewMaterialisationJob() *schema.Resource {
 				Description: "Attributes (key value pairs) to attach to the object",
 				Elem:        attributeSchema(),
 			},
+			"labels_all":     labelsAllSchema(),
+			"attributes_all": attributesAllSchema(),
 			"commit_target": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -122,6 +127,7 @@ func ResourceViewMaterialisationJob() *schema.Resource {
 				Description:   "Branch to run view materialisation for.",
 				ConflictsWith: []string{"commit_target"},
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
@@ -144,13 +150,13 @@ func viewMaterialisationSpecSchema() *schema.Resource {
 	}
 }
 
-func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) error {
+func resourceViewMaterialisationJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	ViewMaterialisationJobID := d.Id()
 
-	ViewMaterialisationJob, err := c.GetViewMaterialisationJob(ViewMaterialisationJobID)
+	ViewMaterialisationJob, err := c.GetViewMaterialisationJob(ctx, ViewMaterialisationJobID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if ViewMaterialisationJob == nil {
 		d.SetId("")
@@ -158,124 +164,133 @@ func resourceViewMaterialisationJobRead(d *schema.ResourceData, m interface{}) e
 	}
 
 	if err := d.Set("name", ViewMaterialisationJob.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", ViewMaterialisationJob.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", ViewMaterialisationJob.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if ViewMaterialisationJob.Type == "batch" {
 		daily, cron, dependency, err := parseSchedule(ViewMaterialisationJob.Schedule)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("daily_schedule", daily); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("cron_schedule", cron); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("dependency_schedule", dependency); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if ViewMaterialisationJob.Principal != nil {
 		if err := d.Set("principal", strconv.Itoa(*ViewMaterialisationJob.Principal)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	views, err := flattenViewMaterialisationSpec(ViewMaterialisationJob.Views)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("view", views); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if ViewMaterialisationJob.UsageTTL != nil {
 		if err := d.Set("usagettl", ViewMaterialisationJob.UsageTTL); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("usagettl", nil)
 	}
 	if err := d.Set("cluster", strconv.Itoa(ViewMaterialisationJob.Cluster)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("cluster_property_sets", identifierList(ViewMaterialisationJob.ClusterPropertySets)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("additional_spark_properties", ViewMaterialisationJob.AdditionalSparkProperties); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	if err := d.Set("labels", ViewMaterialisationJob.Labels); err != nil {
-		return err
+	if err := setLabels(d, c, ViewMaterialisationJob.Labels); err != nil {
+		return diag.FromErr(err)
 	}
-	if err := d.Set("attribute", flattenAttributes(ViewMaterialisationJob.Attributes)); err != nil {
-		return err
+	if err := setAttributes(d, c, ViewMaterialisationJob.Attributes); err != nil {
+		return diag.FromErr(err)
 	}
 
 	if ViewMaterialisationJob.VersionTarget != nil {
 		if ViewMaterialisationJob.VersionTarget.Commit != nil {
 			if err := d.Set("commit_target", ViewMaterialisationJob.VersionTarget.Commit); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if err := d.Set("branch_target", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else if ViewMaterialisationJob.VersionTarget.Branch != nil {
 			if err := d.Set("branch_target", ViewMaterialisationJob.VersionTarget.Branch); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			if err := d.Set("commit_target", nil); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	} else {
 		if err := d.Set("commit_target", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("branch_target", nil); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return err
+	return nil
 }
 
-func resourceViewMaterialisationJobCreate(d *schema.ResourceData, m interface{}) error {
+func resourceViewMaterialisationJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	ViewMaterialisationJob, err := composeViewMaterialisationJob(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	e, err := c.CreateViewMaterialisationJob(*ViewMaterialisationJob)
+	e, err := c.CreateViewMaterialisationJob(ctx, *ViewMaterialisationJob)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "view-materialisation")
 }
 
-func resourceViewMaterialisationJobUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceViewMaterialisationJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	if err := applyDefaults(d, c); err != nil {
+		return diag.FromErr(err)
+	}
 	ViewMaterialisationJobID := d.Id()
 	vm, err := composeViewMaterialisationJob(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = c.UpdateViewMaterialisationJob(ViewMaterialisationJobID, *vm)
+	err = c.UpdateViewMaterialisationJob(ifMatchContext(ctx, d), ViewMaterialisationJobID, *vm)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "view-materialisation")
 }
 
 func composeViewMaterialisationJob(d *schema.ResourceData) (*ViewMaterialisationJob, error) {
@@ -324,13 +339,13 @@ func composeViewMaterialisationJob(d *schema.ResourceData) (*ViewMaterialisation
 	return &viewMateralisationJob, nil
 }
 
-func resourceViewMaterialisationJobDelete(d *schema.ResourceData, m interface{}) error {
+func resourceViewMaterialisationJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	id := d.Id()
 
-	err := c.DeleteViewMaterialisationJob(id)
+	err := c.DeleteViewMaterialisationJob(ctx, id)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,8 +1,10 @@
 package anaml
 
 import (
+	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -18,13 +20,14 @@ as to automate downstream systems using features generated by Anaml.
 
 func ResourceWebhook() *schema.Resource {
 	return &schema.Resource{
-		Description: webhooksDescription,
-		Create:      resourceWebhookCreate,
-		Read:        resourceWebhookRead,
-		Update:      resourceWebhookUpdate,
-		Delete:      resourceWebhookDelete,
+		Description:   webhooksDescription,
+		CreateContext: resourceWebhookCreate,
+		ReadContext:   resourceWebhookRead,
+		UpdateContext: resourceWebhookUpdate,
+		DeleteContext: resourceWebhookDelete,
+		CustomizeDiff: versionDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -89,17 +92,18 @@ func ResourceWebhook() *schema.Resource {
 				MaxItems: 1,
 				Elem:     &schema.Resource{},
 			},
+			"version": objectVersionSchema(),
 		},
 	}
 }
 
-func resourceWebhookRead(d *schema.ResourceData, m interface{}) error {
+func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	webhookID := d.Id()
 
-	webhook, err := c.GetWebhook(webhookID)
+	webhook, err := c.GetWebhook(ctx, webhookID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if webhook == nil {
 		d.SetId("")
@@ -107,42 +111,45 @@ func resourceWebhookRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if err := d.Set("name", webhook.Name); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", webhook.Version); err != nil {
+		return diag.FromErr(err)
 	}
 	if err := d.Set("description", webhook.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("url", webhook.URL); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("merge_requests", flattenEmpty(webhook.MergeRequests)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("merge_request_comments", flattenEmpty(webhook.MergeRequestComments)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("commits", flattenEmpty(webhook.Commits)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("feature_store_runs", flattenEmpty(webhook.FeatureStoreRuns)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("monitoring_runs", flattenEmpty(webhook.MonitoringRuns)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("caching_runs", flattenEmpty(webhook.CachingRuns)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("materialisation_runs", flattenEmpty(webhook.MaterialisationRuns)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("event_store_runs", flattenEmpty(webhook.EventStoreRuns)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return err
+	return nil
 }
 
-func resourceWebhookCreate(d *schema.ResourceData, m interface{}) error {
+func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	webhook := Webhook{
 		Name:                 d.Get("name").(string),
@@ -158,16 +165,16 @@ func resourceWebhookCreate(d *schema.ResourceData, m interface{}) error {
 		EventStoreRuns:       expandEmpty(d.Get("event_store_runs").([]interface{})),
 	}
 
-	e, err := c.CreateWebhook(webhook)
+	e, err := c.CreateWebhook(ctx, webhook)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
 	d.SetId(strconv.Itoa(e.ID))
-	return err
+	return setVersion(ctx, d, c, "webhook")
 }
 
-func resourceWebhookUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	webhookID := d.Id()
 	webhook := Webhook{
@@ -184,21 +191,23 @@ func resourceWebhookUpdate(d *schema.ResourceData, m interface{}) error {
 		EventStoreRuns:       expandEmpty(d.Get("event_store_runs").([]interface{})),
 	}
 
-	err := c.UpdateWebhook(webhookID, webhook)
+	// Webhooks are deployment-wide rather than on a branch. Where Anaml
+	// doesn't version them, the update is sent without If-Match.
+	err := c.UpdateWebhook(ifMatchContext(ctx, d), webhookID, webhook)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(err, nil)
 	}
 
-	return nil
+	return setVersion(ctx, d, c, "webhook")
 }
 
-func resourceWebhookDelete(d *schema.ResourceData, m interface{}) error {
+func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 	webhookID := d.Id()
 
-	err := c.DeleteWebhook(webhookID)
+	err := c.DeleteWebhook(ctx, webhookID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,124 @@
+package anaml
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestRetryPolicy - Controls how transient failures talking to Anaml are retried
+type RequestRetryPolicy struct {
+	MaxRetries int
+	MinWait    time.Duration
+	MaxWait    time.Duration
+}
+
+// DefaultRetryPolicy - Used by NewClient unless the provider overrides it
+var DefaultRetryPolicy = RequestRetryPolicy{
+	MaxRetries: 3,
+	MinWait:    1 * time.Second,
+	MaxWait:    30 * time.Second,
+}
+
+// Methods which the HTTP spec defines as idempotent. Repeating one of these
+// leaves the server in the same state as sending it once, so we can retry
+// them even when we don't know whether the first attempt reached Anaml. The
+// same goes for read only requests, such as previews, whatever their method.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+func isIdempotent(req *http.Request) bool {
+	return idempotentMethods[req.Method] || isRead(req)
+}
+
+// Whether a request which failed before we received a response can be sent
+// again. A POST may have been processed by the server even though the
+// connection was dropped, and repeating it could create a duplicate object.
+func retryableError(req *http.Request, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	return isIdempotent(req)
+}
+
+// Whether a request which received the given response can be sent again.
+// A 429 means the request was rejected without being processed, so that is
+// always safe to repeat. Gateway errors are only retried for idempotent
+// requests, as the upstream may have acted on them before failing.
+func retryableResponse(req *http.Request, res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	default:
+		return false
+	}
+}
+
+// How long to wait before the given (zero based) retry attempt. Exponential
+// backoff from MinWait, capped at MaxWait, with jitter so that many resources
+// failing at once don't all retry in lockstep. A Retry-After header on the
+// response takes precedence, though we still wait no longer than MaxWait so a
+// server asking for a long pause can't stall the apply.
+func (p RequestRetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if wait > p.MaxWait {
+				wait = p.MaxWait
+			}
+			return wait
+		}
+	}
+
+	wait := p.MinWait
+	for i := 0; i < attempt && wait < p.MaxWait; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxWait {
+		wait = p.MaxWait
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+// Retry-After may either be a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
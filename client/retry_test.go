@@ -0,0 +1,70 @@
+package anaml
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func retryAfterResponse(value string) *http.Response {
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", value)
+	return res
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("an empty Retry-After should not be parsed")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("an invalid Retry-After should not be parsed")
+	}
+
+	if wait, ok := parseRetryAfter("5"); !ok || wait != 5*time.Second {
+		t.Errorf("expected 5s from seconds, got %v %v", wait, ok)
+	}
+
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(future); !ok || wait <= 0 || wait > 10*time.Second {
+		t.Errorf("expected up to 10s from an HTTP date, got %v %v", wait, ok)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(past); !ok || wait != 0 {
+		t.Errorf("expected no wait for a date in the past, got %v %v", wait, ok)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RequestRetryPolicy{MaxRetries: 3, MinWait: time.Second, MaxWait: 8 * time.Second}
+
+	for attempt := 0; attempt < 6; attempt++ {
+		limit := policy.MinWait << uint(attempt)
+		if limit > policy.MaxWait {
+			limit = policy.MaxWait
+		}
+		wait := policy.backoff(attempt, nil)
+		if wait < limit/2 || wait > limit {
+			t.Errorf("attempt %d: expected a wait between %v and %v, got %v", attempt, limit/2, limit, wait)
+		}
+	}
+
+	if wait := policy.backoff(0, retryAfterResponse("3")); wait != 3*time.Second {
+		t.Errorf("expected Retry-After in seconds to be used, got %v", wait)
+	}
+
+	if wait := policy.backoff(0, retryAfterResponse(strconv.Itoa(3600))); wait != policy.MaxWait {
+		t.Errorf("expected a long Retry-After to be capped at %v, got %v", policy.MaxWait, wait)
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait := policy.backoff(0, retryAfterResponse(future)); wait != policy.MaxWait {
+		t.Errorf("expected a distant Retry-After date to be capped at %v, got %v", policy.MaxWait, wait)
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	if wait := policy.backoff(0, retryAfterResponse(past)); wait != 0 {
+		t.Errorf("expected no wait for a Retry-After date in the past, got %v", wait)
+	}
+}
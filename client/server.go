@@ -0,0 +1,216 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// MinimumServerVersion - The oldest Anaml release this provider works with.
+// Providers refuse to configure against older servers.
+var MinimumServerVersion = ServerVersion{Major: 1, Minor: 0, Patch: 0}
+
+// ServerVersion - A parsed Anaml release number
+type ServerVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseServerVersion - Parses versions such as "1.4.2", "v1.4" or
+// "1.4.2-SNAPSHOT". Anything after the patch number is ignored.
+func ParseServerVersion(version string) (ServerVersion, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(trimmed, "-+ "); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return ServerVersion{}, fmt.Errorf("Invalid Anaml version %q", version)
+		}
+		numbers[i] = number
+	}
+
+	return ServerVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// AtLeast - Whether v is the same release as other, or a later one
+func (v ServerVersion) AtLeast(other ServerVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+func (v ServerVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "GET", fmt.Sprintf("%s/info", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	info := ServerInfo{}
+	err = json.Unmarshal(body, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// serverVersion - Asks Anaml for its version the first time it's needed.
+// Servers which don't report a version are treated as compatible, as are
+// those reporting a version which can't be parsed, so nil is returned for
+// them.
+func (c *Client) serverVersion(ctx context.Context) (*ServerVersion, error) {
+	c.versionMu.Lock()
+	defer c.versionMu.Unlock()
+
+	if c.versionKnown {
+		return c.version, nil
+	}
+
+	info, err := c.GetServerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if info != nil && info.Version != "" {
+		version, err := ParseServerVersion(info.Version)
+		if err != nil {
+			log.Printf("[WARN] Not checking the Anaml version: %v", err)
+		} else {
+			c.version = &version
+		}
+	}
+	c.versionKnown = true
+	return c.version, nil
+}
+
+// GetCurrentUser - The user the client is authenticated as
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	req, err := http.NewRequestWithContext(withoutVersion(ctx), "GET", fmt.Sprintf("%s/user/self", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, nil
+	}
+
+	user := User{}
+	err = json.Unmarshal(body, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CheckConnection - Makes sure Anaml can be reached with the configured
+// credentials, is a version the provider supports, and, if requireBranch is
+// set, has the configured branch.
+// Providers call this when they're configured, so mistakes are reported once
+// and clearly rather than on the first resource read.
+func (c *Client) CheckConnection(ctx context.Context, requireBranch bool) diag.Diagnostics {
+	user, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return connectionDiagnostics(c.HostURL, err)
+	}
+	// A wrong host or path prefix usually responds with a 404 to everything,
+	// which the client treats as the object not existing.
+	if user == nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Could not connect to Anaml",
+			Detail:   fmt.Sprintf("Anaml at %s responded to %s/user/self with not found, so it doesn't appear to be Anaml's API. Check the host is correct, including any path Anaml is served under. Set skip_credentials_validation to skip this check.", c.HostURL, c.HostURL),
+		}}
+	}
+
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		log.Printf("[WARN] Couldn't find the Anaml version: %v", err)
+	}
+	if version != nil && !version.AtLeast(MinimumServerVersion) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Incompatible Anaml version",
+			Detail:   fmt.Sprintf("Anaml at %s is version %s, but this provider requires %s or later. Set skip_credentials_validation to skip this check.", c.HostURL, version, MinimumServerVersion),
+		}}
+	}
+
+	if requireBranch && c.Branch != nil {
+		branch, err := c.GetBranch(ctx, *c.Branch)
+		if err != nil {
+			return connectionDiagnostics(c.HostURL, err)
+		}
+		if branch == nil {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Branch not found",
+				Detail:   fmt.Sprintf("The branch %q does not exist in Anaml at %s. Create it first, or set create_branch to have the provider create it.", *c.Branch, c.HostURL),
+			}}
+		}
+	}
+
+	return nil
+}
+
+func connectionDiagnostics(host string, err error) diag.Diagnostics {
+	summary := "Could not connect to Anaml"
+	detail := fmt.Sprintf("Requests to %s failed (%v). Check the host is correct and reachable.", host, err)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case IsUnauthorized(err):
+			summary = "Invalid Anaml credentials"
+			detail = fmt.Sprintf("Anaml at %s rejected the configured credentials (%v).", host, err)
+		case IsForbidden(err):
+			summary = "Insufficient Anaml permissions"
+			detail = fmt.Sprintf("The configured credentials aren't permitted to use Anaml at %s (%v).", host, err)
+		default:
+			summary = "Unexpected response from Anaml"
+			detail = fmt.Sprintf("Anaml at %s responded with an error (%v).", host, err)
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail + " Set skip_credentials_validation to skip this check.",
+	}}
+}
@@ -0,0 +1,102 @@
+package anaml
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func checkConnection(t *testing.T, handler http.HandlerFunc, branch *string) string {
+	c := newTestClient(t, handler)
+	c.Branch = branch
+	c.Retry.MaxRetries = 0
+
+	diags := c.CheckConnection(context.Background(), true)
+	if len(diags) == 0 {
+		return ""
+	}
+	return diags[0].Summary
+}
+
+func TestCheckConnection(t *testing.T) {
+	official := "official"
+	missing := "missing"
+
+	anaml := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/self":
+			w.Write([]byte(`{"id":1,"name":"admin"}`))
+		case "/branch/official":
+			w.Write([]byte(`{"name":"official","head":{"id":"c1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// Anaml reporting the given version.
+	versioned := func(version string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/info" {
+				w.Write([]byte(`{"version":"` + version + `"}`))
+				return
+			}
+			anaml(w, r)
+		}
+	}
+
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		branch  *string
+		summary string
+	}{
+		{"connected", anaml, &official, ""},
+		{"missing branch", anaml, &missing, "Branch not found"},
+		{"not Anaml", http.NotFound, &official, "Could not connect to Anaml"},
+		{"bad credentials", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}, &official, "Invalid Anaml credentials"},
+		{"supported version", versioned("1.2.0"), &official, ""},
+		{"unparseable version", versioned("dev"), &official, ""},
+		{"old version", versioned("0.9.3"), &official, "Incompatible Anaml version"},
+	}
+
+	for _, tc := range cases {
+		if summary := checkConnection(t, tc.handler, tc.branch); summary != tc.summary {
+			t.Errorf("%s: expected %q, got %q", tc.name, tc.summary, summary)
+		}
+	}
+}
+
+func TestParseServerVersion(t *testing.T) {
+	cases := map[string]ServerVersion{
+		"1.4.2":          {1, 4, 2},
+		"v1.4":           {1, 4, 0},
+		"1.4.2-SNAPSHOT": {1, 4, 2},
+		"2":              {2, 0, 0},
+	}
+	for input, expected := range cases {
+		version, err := ParseServerVersion(input)
+		if err != nil || version != expected {
+			t.Errorf("%q: expected %v, got %v %v", input, expected, version, err)
+		}
+	}
+
+	if _, err := ParseServerVersion("main"); err == nil {
+		t.Error("expected a version which isn't a number to be rejected")
+	}
+}
+
+func TestServerVersionAtLeast(t *testing.T) {
+	v := ServerVersion{1, 4, 2}
+	for _, other := range []ServerVersion{{1, 4, 2}, {1, 4, 1}, {1, 3, 9}, {0, 9, 0}} {
+		if !v.AtLeast(other) {
+			t.Errorf("expected %v to be at least %v", v, other)
+		}
+	}
+	for _, other := range []ServerVersion{{1, 4, 3}, {1, 5, 0}, {2, 0, 0}} {
+		if v.AtLeast(other) {
+			t.Errorf("expected %v to be older than %v", v, other)
+		}
+	}
+}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetSource(sourceID string) (*Source, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/source/%s", c.HostURL, sourceID), nil)
+func (c *Client) GetSource(ctx context.Context, sourceID string) (*Source, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/source/%s", c.HostURL, sourceID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetSource(sourceID string) (*Source, error) {
 	return &source, nil
 }
 
-func (c *Client) CreateSource(creationRequest Source) (*Source, error) {
+func (c *Client) CreateSource(ctx context.Context, creationRequest Source) (*Source, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/source", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/source", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateSource(creationRequest Source) (*Source, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateSource(sourceID string, creationRequest Source) error {
+func (c *Client) UpdateSource(ctx context.Context, sourceID string, creationRequest Source) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/source/%s", c.HostURL, sourceID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/source/%s", c.HostURL, sourceID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateSource(sourceID string, creationRequest Source) error {
 	return nil
 }
 
-func (c *Client) DeleteSource(sourceID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/source/%s", c.HostURL, sourceID), nil)
+func (c *Client) DeleteSource(ctx context.Context, sourceID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/source/%s", c.HostURL, sourceID), nil)
 	if err != nil {
 		return err
 	}
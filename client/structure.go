@@ -131,6 +131,15 @@ func validateAnamlIdentifier() schema.SchemaValidateFunc {
 	return validation.StringMatch(identifierPattern, "Must be parsable as an integer")
 }
 
+func validateDate() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		if _, err := time.Parse(previewDateFormat, i.(string)); err != nil {
+			return nil, []error{fmt.Errorf("%s must be a date as YYYY-MM-DD: %v", k, err)}
+		}
+		return nil, nil
+	}
+}
+
 func ValidateDuration() schema.SchemaValidateFunc {
 	return func(i interface{}, k string) ([]string, []error) {
 		_, err := time.ParseDuration(i.(string))
@@ -141,6 +150,16 @@ func ValidateDuration() schema.SchemaValidateFunc {
 	}
 }
 
+func ValidateCommitMessage() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		_, err := ParseCommitMessage(i.(string))
+		if err != nil {
+			return nil, []error{err}
+		}
+		return nil, nil
+	}
+}
+
 func validateMapKeysAnamlIdentifier() schema.SchemaValidateDiagFunc {
 	return validation.MapKeyMatch(identifierPattern, "Map keys must be parsable as an integer")
 }
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetTable(tableID string) (*Table, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/table/%s", c.HostURL, tableID), nil)
+func (c *Client) GetTable(ctx context.Context, tableID string) (*Table, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/table/%s", c.HostURL, tableID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,13 +32,13 @@ func (c *Client) GetTable(tableID string) (*Table, error) {
 	return &table, nil
 }
 
-func (c *Client) CreateTable(creationRequest Table) (*Table, error) {
+func (c *Client) CreateTable(ctx context.Context, creationRequest Table) (*Table, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/table", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/table", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +58,13 @@ func (c *Client) CreateTable(creationRequest Table) (*Table, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateTable(tableID string, creationRequest Table) error {
+func (c *Client) UpdateTable(ctx context.Context, tableID string, creationRequest Table) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/table/%s", c.HostURL, tableID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/table/%s", c.HostURL, tableID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -76,8 +77,8 @@ func (c *Client) UpdateTable(tableID string, creationRequest Table) error {
 	return nil
 }
 
-func (c *Client) DeleteTable(tableId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/table/%s", c.HostURL, tableId), nil)
+func (c *Client) DeleteTable(ctx context.Context, tableId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/table/%s", c.HostURL, tableId), nil)
 	if err != nil {
 		return err
 	}
@@ -90,8 +91,8 @@ func (c *Client) DeleteTable(tableId string) error {
 	return nil
 }
 
-func (c *Client) FindTableByName(name string) (*Table, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/table", c.HostURL), nil)
+func (c *Client) FindTableByName(ctx context.Context, name string) (*Table, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/table", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -117,3 +118,27 @@ func (c *Client) FindTableByName(name string) (*Table, error) {
 
 	return &item, nil
 }
+
+func (c *Client) ListTables(ctx context.Context) ([]Table, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/table", c.HostURL), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	items := []Table{}
+	err = json.Unmarshal(body, &items)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
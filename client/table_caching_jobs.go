@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetTableCaching(TableCachingId string) (*TableCaching, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/table-caching/%s", c.HostURL, TableCachingId), nil)
+func (c *Client) GetTableCaching(ctx context.Context, TableCachingId string) (*TableCaching, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/table-caching/%s", c.HostURL, TableCachingId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetTableCaching(TableCachingId string) (*TableCaching, error) {
 	return &TableCachingJob, nil
 }
 
-func (c *Client) CreateTableCaching(creationRequest TableCaching) (*TableCaching, error) {
+func (c *Client) CreateTableCaching(ctx context.Context, creationRequest TableCaching) (*TableCaching, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/table-caching", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/table-caching", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateTableCaching(creationRequest TableCaching) (*TableCaching
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateTableCaching(TableCachingId string, creationRequest TableCaching) error {
+func (c *Client) UpdateTableCaching(ctx context.Context, TableCachingId string, creationRequest TableCaching) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/table-caching/%s", c.HostURL, TableCachingId), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/table-caching/%s", c.HostURL, TableCachingId), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateTableCaching(TableCachingId string, creationRequest Table
 	return nil
 }
 
-func (c *Client) DeleteTableCaching(TableCachingId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/table-caching/%s", c.HostURL, TableCachingId), nil)
+func (c *Client) DeleteTableCaching(ctx context.Context, TableCachingId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/table-caching/%s", c.HostURL, TableCachingId), nil)
 	if err != nil {
 		return err
 	}
@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetTableMonitoring(TableMonitoringId string) (*TableMonitoring, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), nil)
+func (c *Client) GetTableMonitoring(ctx context.Context, TableMonitoringId string) (*TableMonitoring, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetTableMonitoring(TableMonitoringId string) (*TableMonitoring,
 	return &TableMonitoringJob, nil
 }
 
-func (c *Client) CreateTableMonitoring(creationRequest TableMonitoring) (*TableMonitoring, error) {
+func (c *Client) CreateTableMonitoring(ctx context.Context, creationRequest TableMonitoring) (*TableMonitoring, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/table-monitoring", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/table-monitoring", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateTableMonitoring(creationRequest TableMonitoring) (*TableM
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateTableMonitoring(TableMonitoringId string, creationRequest TableMonitoring) error {
+func (c *Client) UpdateTableMonitoring(ctx context.Context, TableMonitoringId string, creationRequest TableMonitoring) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateTableMonitoring(TableMonitoringId string, creationRequest
 	return nil
 }
 
-func (c *Client) DeleteTableMonitoring(TableMonitoringId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), nil)
+func (c *Client) DeleteTableMonitoring(ctx context.Context, TableMonitoringId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/table-monitoring/%s", c.HostURL, TableMonitoringId), nil)
 	if err != nil {
 		return err
 	}
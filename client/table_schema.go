@@ -0,0 +1,66 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GetTableSchema - The columns of an existing table
+func (c *Client) GetTableSchema(ctx context.Context, tableID string) (*TableSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/table/%s/schema", c.HostURL, tableID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	tableSchema := TableSchema{}
+	err = json.Unmarshal(body, &tableSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableSchema, nil
+}
+
+// InferSourceSchema - The columns Anaml infers for the data at a location in
+// a source. Sources don't belong to a branch, so this is never sent to one,
+// and inferring a schema doesn't change anything, so it's sent as a read.
+func (c *Client) InferSourceSchema(ctx context.Context, location SourceReference) (*TableSchema, error) {
+	rb, err := json.Marshal(location)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(readOnly(withoutVersion(ctx)), "POST", fmt.Sprintf("%s/source/schema", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		return nil, nil
+	}
+
+	tableSchema := TableSchema{}
+	err = json.Unmarshal(body, &tableSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableSchema, nil
+}
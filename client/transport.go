@@ -0,0 +1,109 @@
+package anaml
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig - TLS, proxy and header settings for talking to Anaml.
+// Certificates and keys may be given either as a path to a PEM file or as
+// PEM encoded content, but not both.
+type TransportConfig struct {
+	CACertFile         string
+	CACertPEM          string
+	ClientCertFile     string
+	ClientCertPEM      string
+	ClientKeyFile      string
+	ClientKeyPEM       string
+	InsecureSkipVerify bool
+	ProxyURL           string
+	ExtraHeaders       map[string]string
+}
+
+// ConfigureTransport - Replaces the client's transport with one built from config
+func (c *Client) ConfigureTransport(config TransportConfig) error {
+	transport, err := config.newTransport()
+	if err != nil {
+		return err
+	}
+
+	c.HTTPClient.Transport = transport
+	c.ExtraHeaders = config.ExtraHeaders
+	return nil
+}
+
+func (config TransportConfig) newTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	caCert, err := readPEM("CA certificate", config.CACertFile, config.CACertPEM)
+	if err != nil {
+		return nil, err
+	}
+	if caCert != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("No certificates could be parsed from the CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert, err := readPEM("client certificate", config.ClientCertFile, config.ClientCertPEM)
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := readPEM("client key", config.ClientKeyFile, config.ClientKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if (clientCert == nil) != (clientKey == nil) {
+		return nil, errors.New("A client certificate and client key must be set together")
+	}
+	if clientCert != nil {
+		pair, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return transport, nil
+}
+
+func readPEM(name string, file string, content string) ([]byte, error) {
+	if file != "" && content != "" {
+		return nil, fmt.Errorf("Only one of a file or PEM content may be set for the %s", name)
+	}
+	if content != "" {
+		return []byte(content), nil
+	}
+	if file != "" {
+		bytes, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Reading %s: %w", name, err)
+		}
+		return bytes, nil
+	}
+	return nil, nil
+}
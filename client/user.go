@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetUser(userID string) (*User, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user/%s", c.HostURL, userID), nil)
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/user/%s", c.HostURL, userID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,8 +31,8 @@ func (c *Client) GetUser(userID string) (*User, error) {
 	return &user, nil
 }
 
-func (c *Client) FindUserByEmail(email string) (*User, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user", c.HostURL), nil)
+func (c *Client) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/user", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +58,13 @@ func (c *Client) FindUserByEmail(email string) (*User, error) {
 	return &user, nil
 }
 
-func (c *Client) CreateUser(creationRequest User) (*User, error) {
+func (c *Client) CreateUser(ctx context.Context, creationRequest User) (*User, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/user", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -83,13 +84,13 @@ func (c *Client) CreateUser(creationRequest User) (*User, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateUser(userID string, creationRequest User) error {
+func (c *Client) UpdateUser(ctx context.Context, userID string, creationRequest User) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/user/%s", c.HostURL, userID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/user/%s", c.HostURL, userID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -102,8 +103,8 @@ func (c *Client) UpdateUser(userID string, creationRequest User) error {
 	return nil
 }
 
-func (c *Client) DeleteUser(userID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/user/%s", c.HostURL, userID), nil)
+func (c *Client) DeleteUser(ctx context.Context, userID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/user/%s", c.HostURL, userID), nil)
 	if err != nil {
 		return err
 	}
@@ -116,14 +117,14 @@ func (c *Client) DeleteUser(userID string) error {
 	return nil
 }
 
-func (c *Client) UpdateUserPassword(userID string, password *string) error {
+func (c *Client) UpdateUserPassword(ctx context.Context, userID string, password *string) error {
 	updateRequest := ChangeOtherPasswordRequest{Password: password}
 	rb, err := json.Marshal(updateRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/user/%s/change-password", c.HostURL, userID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/user/%s/change-password", c.HostURL, userID), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
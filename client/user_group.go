@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetUserGroup(userGroupId string) (*UserGroup, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), nil)
+func (c *Client) GetUserGroup(ctx context.Context, userGroupId string) (*UserGroup, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetUserGroup(userGroupId string) (*UserGroup, error) {
 	return &userGroup, nil
 }
 
-func (c *Client) CreateUserGroup(creationRequest UserGroup) (*UserGroup, error) {
+func (c *Client) CreateUserGroup(ctx context.Context, creationRequest UserGroup) (*UserGroup, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/user-group", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/user-group", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateUserGroup(creationRequest UserGroup) (*UserGroup, error)
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateUserGroup(userGroupId string, creationRequest UserGroup) error {
+func (c *Client) UpdateUserGroup(ctx context.Context, userGroupId string, creationRequest UserGroup) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateUserGroup(userGroupId string, creationRequest UserGroup)
 	return nil
 }
 
-func (c *Client) DeleteUserGroup(userGroupId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), nil)
+func (c *Client) DeleteUserGroup(ctx context.Context, userGroupId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/user-group/%s", c.HostURL, userGroupId), nil)
 	if err != nil {
 		return err
 	}
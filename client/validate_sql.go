@@ -0,0 +1,121 @@
+package anaml
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// With validate_sql set, features and view tables are previewed on the
+// preview cluster while planning, so SQL Anaml can't run fails the plan
+// rather than the first job which computes it.
+
+const previewDateFormat = "2006-01-02"
+
+// Keys of a feature which change the SQL it runs.
+var featureSQLKeys = []string{
+	"table", "select", "filter", "aggregation", "post_aggregation", "hours", "days", "months", "rows",
+	"entity", "over", "entity_restrictions",
+}
+
+func validateSQLSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Preview changes on the preview cluster while planning, so SQL which Anaml can't run fails the plan. Plans take longer, as each change is computed.",
+	}
+}
+
+// validateFeatureSQLDiff previews a new or changed feature.
+func validateFeatureSQLDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c, ok := m.(*Client)
+	if !ok || !d.Get("validate_sql").(bool) || !sqlChanged(d, featureSQLKeys...) {
+		return nil
+	}
+
+	feature, err := buildFeature(d)
+	if err != nil {
+		return err
+	}
+
+	return validateSQL(ctx, d, c, func(ctx context.Context, cluster int, date string) error {
+		_, err := c.PreviewFeature(ctx, FeaturePreviewRequest{
+			Feature: feature,
+			Cluster: cluster,
+			Date:    date,
+			Limit:   1,
+		})
+		return err
+	})
+}
+
+// validateViewSQLDiff previews a new or changed view table. Other kinds of
+// table have no SQL of their own.
+func validateViewSQLDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c, ok := m.(*Client)
+	if !ok || !d.Get("validate_sql").(bool) || !sqlChanged(d, "view") {
+		return nil
+	}
+
+	view, _ := expandSingleMap(d.Get("view"))
+	if view == nil {
+		return nil
+	}
+
+	expression, sources := expandViewSpecification(view)
+	table := Table{
+		Name:       d.Get("name").(string),
+		Type:       "view",
+		Expression: expression,
+		Sources:    sources,
+	}
+
+	return validateSQL(ctx, d, c, func(ctx context.Context, cluster int, date string) error {
+		_, err := c.PreviewTable(ctx, TablePreviewRequest{
+			Table:   table,
+			Cluster: cluster,
+			Date:    date,
+			Limit:   1,
+		})
+		return err
+	})
+}
+
+// Whether the object is new or any of the keys have changed. Objects
+// depending on values which aren't known until apply can't be previewed.
+func sqlChanged(d *schema.ResourceDiff, keys ...string) bool {
+	changed := d.Id() == ""
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			log.Printf("[INFO] Not validating the SQL of %s, as %s isn't known until apply", d.Get("name"), key)
+			return false
+		}
+		if d.HasChange(key) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Runs the preview against the resource's branch, on the preview cluster, for
+// yesterday.
+func validateSQL(ctx context.Context, d *schema.ResourceDiff, c *Client, preview func(context.Context, int, string) error) error {
+	if branch, ok := d.GetOk("branch"); ok {
+		ctx = WithBranch(ctx, branch.(string))
+	}
+
+	cluster, err := c.previewCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("Can't validate the SQL of %s: %w", d.Get("name"), err)
+	}
+
+	date := time.Now().UTC().AddDate(0, 0, -1).Format(previewDateFormat)
+	if err := preview(ctx, cluster, date); err != nil {
+		return fmt.Errorf("The SQL of %s failed to run on preview cluster %d: %w", d.Get("name"), cluster, err)
+	}
+	return nil
+}
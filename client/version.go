@@ -0,0 +1,35 @@
+package anaml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GetVersion - The current version of an object, such as a "feature" or
+// "feature-set", without decoding the rest of it
+func (c *Client) GetVersion(ctx context.Context, kind string, id string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/%s", c.HostURL, kind, id), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+	if body == nil {
+		return "", nil
+	}
+
+	versioned := struct {
+		Version string `json:"version"`
+	}{}
+	err = json.Unmarshal(body, &versioned)
+	if err != nil {
+		return "", err
+	}
+
+	return versioned.Version, nil
+}
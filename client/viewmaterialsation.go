@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetViewMaterialisationJob(id string) (*ViewMaterialisationJob, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), nil)
+func (c *Client) GetViewMaterialisationJob(ctx context.Context, id string) (*ViewMaterialisationJob, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetViewMaterialisationJob(id string) (*ViewMaterialisationJob,
 	return &MaterialisedView, nil
 }
 
-func (c *Client) CreateViewMaterialisationJob(creationRequest ViewMaterialisationJob) (*ViewMaterialisationJob, error) {
+func (c *Client) CreateViewMaterialisationJob(ctx context.Context, creationRequest ViewMaterialisationJob) (*ViewMaterialisationJob, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/view-materialisation", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/view-materialisation", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateViewMaterialisationJob(creationRequest ViewMaterialisatio
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateViewMaterialisationJob(id string, creationRequest ViewMaterialisationJob) error {
+func (c *Client) UpdateViewMaterialisationJob(ctx context.Context, id string, creationRequest ViewMaterialisationJob) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateViewMaterialisationJob(id string, creationRequest ViewMat
 	return nil
 }
 
-func (c *Client) DeleteViewMaterialisationJob(id string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), nil)
+func (c *Client) DeleteViewMaterialisationJob(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/view-materialisation/%s", c.HostURL, id), nil)
 	if err != nil {
 		return err
 	}
@@ -89,8 +90,8 @@ func (c *Client) DeleteViewMaterialisationJob(id string) error {
 	return nil
 }
 
-func (c *Client) FindViewMaterialisationJobByName(name string) (*ViewMaterialisationJob, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/view-materialisation", c.HostURL), nil)
+func (c *Client) FindViewMaterialisationJobByName(ctx context.Context, name string) (*ViewMaterialisationJob, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/view-materialisation", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
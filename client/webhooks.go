@@ -1,14 +1,15 @@
 package anaml
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 )
 
-func (c *Client) GetWebhook(WebhookId string) (*Webhook, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), nil)
+func (c *Client) GetWebhook(ctx context.Context, WebhookId string) (*Webhook, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -30,13 +31,13 @@ func (c *Client) GetWebhook(WebhookId string) (*Webhook, error) {
 	return &Webhook, nil
 }
 
-func (c *Client) CreateWebhook(creationRequest Webhook) (*Webhook, error) {
+func (c *Client) CreateWebhook(ctx context.Context, creationRequest Webhook) (*Webhook, error) {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/webhook", c.HostURL), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/webhook", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +57,13 @@ func (c *Client) CreateWebhook(creationRequest Webhook) (*Webhook, error) {
 	return &creationRequest, nil
 }
 
-func (c *Client) UpdateWebhook(WebhookId string, creationRequest Webhook) error {
+func (c *Client) UpdateWebhook(ctx context.Context, WebhookId string, creationRequest Webhook) error {
 	rb, err := json.Marshal(creationRequest)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), strings.NewReader(string(rb)))
 	if err != nil {
 		return err
 	}
@@ -75,8 +76,8 @@ func (c *Client) UpdateWebhook(WebhookId string, creationRequest Webhook) error
 	return nil
 }
 
-func (c *Client) DeleteWebhook(WebhookId string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), nil)
+func (c *Client) DeleteWebhook(ctx context.Context, WebhookId string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/webhook/%s", c.HostURL, WebhookId), nil)
 	if err != nil {
 		return err
 	}
@@ -1,55 +1,39 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	anaml "anaml.io/terraform/client"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
 	provider := schema.Provider{
-		Schema: map[string]*schema.Schema{
-			"host": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("ANAML_HOST", nil),
-			},
-			"username": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("ANAML_USERNAME", nil),
-			},
-			"password": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("ANAML_PASSWORD", nil),
-			},
-			"branch": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("ANAML_DEFAULT_BRANCH", nil),
-			},
-			"request_timeout": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      "30s",
-				ValidateFunc: anaml.ValidateDuration(),
-			},
-		},
+		Schema: providerSchema(),
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"anaml_entity":            anaml.DataSourceEntity(),
+			"anaml_entities":          anaml.DataSourceEntities(),
 			"anaml_entity_population": anaml.DataSourceEntityPopulation(),
 			"anaml_table":             anaml.DataSourceTable(),
+			"anaml_table_schema":      anaml.DataSourceTableSchema(),
+			"anaml_tables":            anaml.DataSourceTables(),
 			"anaml_feature":           anaml.DataSourceFeature(),
+			"anaml_feature_preview":   anaml.DataSourceFeaturePreview(),
+			"anaml_features":          anaml.DataSourceFeatures(),
 			"anaml_feature_set":       anaml.DataSourceFeatureSet(),
+			"anaml_feature_sets":      anaml.DataSourceFeatureSets(),
 			"anaml_feature_template":  anaml.DataSourceFeatureTemplate(),
+			"anaml_lineage":           anaml.DataSourceLineage(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"anaml_branch":            anaml.ResourceBranch(),
 			"anaml_entity":            anaml.ResourceEntity(),
 			"anaml_entity_mapping":    anaml.ResourceEntityMapping(),
 			"anaml_entity_population": anaml.ResourceEntityPopulation(),
@@ -58,35 +42,141 @@ func Provider() *schema.Provider {
 			"anaml_feature_set":       anaml.ResourceFeatureSet(),
 			"anaml_feature_template":  anaml.ResourceFeatureTemplate(),
 			"anaml_metrics_set":       anaml.ResourceMetricsSet(),
+			"anaml_merge_request":     anaml.ResourceMergeRequest(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
+	}
+
+	// The anaml-operations resources are served as well, so that one provider
+	// can manage a whole deployment. They're also registered under their
+	// original names, so state moved here with `terraform state
+	// replace-provider` keeps working.
+	for _, prefix := range []string{"anaml_", "anaml-operations_"} {
+		for name, resource := range anaml.OperationsResources() {
+			provider.ResourcesMap[prefix+name] = anaml.WithoutBranch(resource)
+		}
+		for name, dataSource := range anaml.OperationsDataSources() {
+			provider.DataSourcesMap[prefix+name] = anaml.WithoutBranch(dataSource)
+		}
 	}
+
 	return &provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
+// The common settings, and those for the branch objects are written to.
+func providerSchema() map[string]*schema.Schema {
+	providerSchema := anaml.CommonProviderSchema()
+	for key, value := range map[string]*schema.Schema{
+		"branch": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_DEFAULT_BRANCH", nil),
+		},
+		"create_branch": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Create the configured branch from create_branch_from if it doesn't exist yet",
+		},
+		"create_branch_from": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "official",
+			Description:  "The branch the configured branch is created from when create_branch is set",
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+		"branch_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "direct",
+			Description:  "Either direct, to write straight to the configured branch, or ephemeral, to write to a new branch and open a merge request into the configured branch",
+			ValidateFunc: validation.StringInSlice([]string{"direct", "ephemeral"}, false),
+		},
+		"ephemeral_branch_prefix": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "terraform-",
+			Description:  "The start of the name of branches created in ephemeral mode. The run id is appended to it. A branch with this prefix whose merge request is still open is written to instead",
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+		"run_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Identifies the apply in ephemeral branch names, such as a CI build number. Defaults to the current time",
+			DefaultFunc: schema.EnvDefaultFunc("ANAML_RUN_ID", nil),
+		},
+		"commit_message": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Template for the message of commits made by writes, for example \"{{.Operation}} {{.Object}} on {{.Branch}}\". Can refer to Operation, ResourceType, Name, ID, Object, Branch and Workspace",
+			ValidateFunc: anaml.ValidateCommitMessage(),
+		},
+		"preview_cluster": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "The id of the cluster to run feature previews and validate_sql on. Defaults to the only cluster with is_preview_cluster set",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	} {
+		providerSchema[key] = value
+	}
+	return providerSchema
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	branch := d.Get("branch").(string)
 
-	var host *string
+	c, diags := anaml.ConfigureClient(d, &branch)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if cluster, ok := d.GetOk("preview_cluster"); ok {
+		previewCluster := cluster.(int)
+		c.PreviewCluster = &previewCluster
+	}
 
-	hVal, ok := d.GetOk("host")
-	if ok {
-		tempHost := hVal.(string)
-		host = &tempHost
+	if text := d.Get("commit_message").(string); text != "" {
+		message, err := anaml.ParseCommitMessage(text)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		c.CommitMessage = message
 	}
 
-	timeout, err := time.ParseDuration(d.Get("request_timeout").(string))
+	if d.Get("branch_mode").(string) == "ephemeral" {
+		runID := d.Get("run_id").(string)
+		if runID == "" {
+			runID = time.Now().UTC().Format("20060102150405")
+		}
+
+		err := c.UseEphemeralBranch(anaml.EphemeralBranchConfig{
+			Name:                    d.Get("ephemeral_branch_prefix").(string) + runID,
+			Prefix:                  d.Get("ephemeral_branch_prefix").(string),
+			MergeRequestTitle:       fmt.Sprintf("Terraform apply %s", runID),
+			MergeRequestDescription: fmt.Sprintf("Changes to %s applied by Terraform", branch),
+		})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
 
-	if err != nil {
-		return nil, err
+	if !d.Get("skip_credentials_validation").(bool) {
+		if diags := c.CheckConnection(ctx, !d.Get("create_branch").(bool)); diags.HasError() {
+			return nil, diags
+		}
 	}
 
-	c, err := anaml.NewClient(host, &username, &password, &branch, timeout)
-	if err != nil {
-		return nil, err
+	if d.Get("create_branch").(bool) && branch != "" {
+		from := d.Get("create_branch_from").(string)
+		_, err := c.CreateBranchIfMissing(ctx, anaml.BranchCreationRequest{
+			Name: branch,
+			From: anaml.VersionTarget{Type: "branch", Branch: &from},
+		})
+		if err != nil {
+			return nil, diag.Errorf("Creating branch %s: %v", branch, err)
+		}
 	}
 
 	return c, nil